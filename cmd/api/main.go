@@ -2,12 +2,33 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	nethttp "net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/JoaoVitorFerreiro/golang-start/config"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
 	"github.com/JoaoVitorFerreiro/golang-start/internal/application/service"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/authn"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/broadcast"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/events"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/health"
 	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/http"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/i18n"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/logging"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/mail"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/metrics"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/password"
 	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/tracing"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/webhook"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	swaggerFiles "github.com/swaggo/files"
@@ -32,40 +53,353 @@ import (
 // @host      localhost:8080
 // @BasePath  /
 
-// @securityDefinitions.basic  BasicAuth
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 Chave de API estática cadastrada em AUTH_API_KEYS
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Token JWT no formato "Bearer {token}"
 
 func main() {
     cfg := config.Load()
+    logger := logging.New(os.Stdout, cfg.LogLevel, logging.Format(cfg.LogFormat))
+    slog.SetDefault(logger)
+    
+    if len(os.Args) > 1 && os.Args[1] == "migrate" {
+        if err := runMigrate(cfg, logger, os.Args[2:]); err != nil {
+            logger.Error("migration failed", "error", err)
+            os.Exit(1)
+        }
+        return
+    }
+    
+    if err := run(cfg, logger); err != nil {
+        logger.Error("server exited with error", "error", err)
+        os.Exit(1)
+    }
+}
+
+// run sobe a API e bloqueia até receber SIGINT/SIGTERM. Todo o encerramento
+// acontece aqui, então os defers rodam mesmo quando a inicialização falha.
+func run(cfg *config.Config, logger *slog.Logger) error {
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
     
     gin.SetMode(cfg.GinMode)
     
-    var userRepo repository.UserRepository
+    shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+        Exporter:     tracing.Exporter(cfg.TracingExporter),
+        Environment:  cfg.Env,
+        OTLPEndpoint: cfg.TracingEndpoint,
+        FilePath:     cfg.TracingFile,
+        SampleRatio:  cfg.TracingSampleRatio,
+    })
+    if err != nil {
+        return fmt.Errorf("failed to set up tracing: %w", err)
+    }
+    defer func() {
+        ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+        defer cancel()
+        if err := shutdownTracing(ctx); err != nil {
+            logger.Error("failed to flush traces", "error", err)
+        }
+    }()
+    
+    var (
+        userRepo         repository.UserRepository
+        tokenRepo        repository.RefreshTokenRepository
+        oneTimeTokenRepo repository.OneTimeTokenRepository
+        outboxRepo       repository.OutboxRepository
+        webhookRepo      repository.WebhookRepository
+        deliveryRepo     repository.WebhookDeliveryRepository
+        txManager        repository.TxManager
+        changes          broadcast.Broadcaster
+    )
+    healthRegistry := health.NewRegistry(cfg.HealthCheckTimeout)
+    appMetrics := metrics.New()
+    hub := broadcast.NewHub(int(cfg.UserEventsBuffer), int(cfg.UserEventsClientBuffer))
+    
+    // Os repositórios de dentro das transações são medidos como os do pool
+    observeUsers := appMetrics.QueryObserver("users")
+    instrumentTx := repository.WithTxDecorator(func(repos repository.Repositories) repository.Repositories {
+        repos.Users = repository.NewInstrumentedUserRepository(repos.Users, observeUsers)
+        return repos
+    })
     
     if cfg.Env == "production" || cfg.Env == "staging" {
         pool, err := setupDatabase(cfg)
         if err != nil {
-            log.Fatal("Failed to connect to database:", err)
+            return fmt.Errorf("failed to connect to database: %w", err)
+        }
+        defer func() {
+            pool.Close()
+            logger.Info("database pool closed")
+        }()
+        
+        if cfg.DBAutoMigrate {
+            if err := migrateOnStart(pool, logger); err != nil {
+                return fmt.Errorf("failed to run migrations: %w", err)
+            }
+        }
+        
+        repoOpts := []repository.PostgresOption{
+            repository.WithQueryTimeout(cfg.DBQueryTimeout),
+            repository.WithLogger(logger),
+        }
+        userRepo = repository.NewUserRepository(repository.Postgres, pool, repoOpts...)
+        tokenRepo = repository.NewRefreshTokenRepository(repository.Postgres, pool, repoOpts...)
+        oneTimeTokenRepo = repository.NewOneTimeTokenRepository(repository.Postgres, pool, repoOpts...)
+        outboxRepo = repository.NewOutboxRepository(repository.Postgres, pool, repoOpts...)
+        webhookRepo = repository.NewWebhookRepository(repository.Postgres, pool, repoOpts...)
+        deliveryRepo = repository.NewWebhookDeliveryRepository(repository.Postgres, pool, repoOpts...)
+        
+        isolation, err := repository.ParseTxIsolation(cfg.DBTxIsolation)
+        if err != nil {
+            return err
         }
-        defer pool.Close()
+        txManager = repository.NewTxManager(repository.Postgres, pool, repository.Repositories{},
+            repository.WithTxIsolation(isolation),
+            repository.WithTxMaxRetries(int(cfg.DBTxMaxRetries)),
+            repository.WithTxRepositoryOptions(repoOpts...),
+            repository.WithTxLogger(logger),
+            instrumentTx,
+        )
+        
+        // Cada réplica recebe pelo LISTEN as alterações feitas em todas
+        listenerCtx, stopListener := context.WithCancel(ctx)
+        listenerDone := make(chan struct{})
+        defer func() {
+            stopListener()
+            <-listenerDone
+        }()
+        pgBroadcaster := broadcast.NewPostgresBroadcaster(pool, hub, logger)
+        go func() {
+            defer close(listenerDone)
+            pgBroadcaster.Run(listenerCtx)
+        }()
+        changes = pgBroadcaster
         
-        userRepo = repository.NewUserRepository(repository.Postgres, pool)
-        log.Println("Using PostgreSQL repository")
+        healthRegistry.Register("postgres", health.PostgresCheck(pool))
+        appMetrics.Register(metrics.NewPoolCollector(pool))
+        logger.Info("using PostgreSQL repository")
     } else {
         userRepo = repository.NewUserRepository(repository.InMemory, nil)
-        log.Println("Using in-memory repository")
+        tokenRepo = repository.NewRefreshTokenRepository(repository.InMemory, nil)
+        oneTimeTokenRepo = repository.NewOneTimeTokenRepository(repository.InMemory, nil)
+        outboxRepo = repository.NewOutboxRepository(repository.InMemory, nil)
+        webhookRepo = repository.NewWebhookRepository(repository.InMemory, nil)
+        deliveryRepo = repository.NewWebhookDeliveryRepository(repository.InMemory, nil)
+        txManager = repository.NewTxManager(repository.InMemory, nil, repository.Repositories{
+            Users:         userRepo,
+            Outbox:        outboxRepo,
+            RefreshTokens: tokenRepo,
+            OneTimeTokens: oneTimeTokenRepo,
+        }, instrumentTx)
+        changes = hub
+        logger.Info("using in-memory repository")
     }
     
-    userService := service.NewUserService(userRepo)    
+    userRepo = repository.NewInstrumentedUserRepository(userRepo, observeUsers)
+    
+    hasher, err := password.NewHasher(password.Algorithm(cfg.PasswordHashAlgorithm))
+    if err != nil {
+        return err
+    }
+    
+    emailPolicy, err := loadEmailPolicy(cfg)
+    if err != nil {
+        return fmt.Errorf("failed to load email policy: %w", err)
+    }
+    
+    mailer, err := mail.NewMailer(mail.Config{
+        Driver:   mail.Driver(cfg.MailDriver),
+        FilePath: cfg.MailFile,
+        // Os links levam tokens de uso único: corpo no log só em desenvolvimento
+        LogBody: cfg.MailLogBody && cfg.Env != "production" && cfg.Env != "staging",
+        SMTP: mail.SMTPConfig{
+            Host:     cfg.SMTPHost,
+            Port:     int(cfg.SMTPPort),
+            Username: cfg.SMTPUsername,
+            Password: cfg.SMTPPassword,
+            From:     cfg.MailFrom,
+            Timeout:  cfg.MailSendTimeout,
+        },
+    }, logger)
+    if err != nil {
+        return fmt.Errorf("failed to set up mailer: %w", err)
+    }
+    
+    verificationService := service.NewEmailVerificationService(userRepo, oneTimeTokenRepo, mailer, cfg.AppBaseURL+"/verify",
+        service.WithVerificationTTL(cfg.EmailVerificationTTL),
+        service.WithVerificationTxManager(txManager),
+        service.WithVerificationLogger(logger),
+    )
+    userService := service.NewUserService(userRepo,
+        service.WithLogger(logger),
+        service.WithPasswordHasher(hasher),
+        service.WithEmailVerification(verificationService),
+        service.WithEmailPolicy(emailPolicy),
+        service.WithTxManager(txManager),
+        service.WithBroadcaster(changes),
+    )
+    resetService := service.NewPasswordResetService(userRepo, oneTimeTokenRepo, tokenRepo, hasher, mailer, cfg.PasswordResetURL,
+        service.WithResetTTL(cfg.PasswordResetTTL),
+        service.WithResetTxManager(txManager),
+        service.WithResetRateLimits(int(cfg.PasswordResetEmailLimit), int(cfg.PasswordResetIPLimit), cfg.PasswordResetWindow),
+        service.WithResetEmailPolicy(emailPolicy),
+        service.WithResetLogger(logger),
+    )
+    webhookService := service.NewWebhookService(webhookRepo, deliveryRepo, service.WithWebhookLogger(logger))
     userHandler := http.NewUserHandler(userService)
+    verificationHandler := http.NewEmailVerificationHandler(verificationService)
+    resetHandler := http.NewPasswordResetHandler(resetService)
+    healthHandler := http.NewHealthHandler(healthRegistry)
+    
+    jwtConfig, err := loadJWTConfig(cfg)
+    if err != nil {
+        return fmt.Errorf("failed to load JWT configuration: %w", err)
+    }
+    authMiddleware, err := setupAuth(cfg, jwtConfig, logger)
+    if err != nil {
+        return fmt.Errorf("failed to set up authentication: %w", err)
+    }
+    
+    catalog, err := i18n.NewCatalog(cfg.DefaultLocale)
+    if err != nil {
+        return fmt.Errorf("failed to load message catalog: %w", err)
+    }
+    
+    router, err := setupRouter(appMetrics, catalog, cfg.TrustedProxies, logger)
+    if err != nil {
+        return fmt.Errorf("failed to set up router: %w", err)
+    }
+    userHandler.RegisterRoutes(router.Group("", authMiddleware))
+    http.NewWebhookHandler(webhookService).RegisterRoutes(router.Group("", authMiddleware))
+    eventsHandler := http.NewUserEventsHandler(userService, cfg.UserEventsHeartbeat)
+    eventsHandler.RegisterRoutes(router.Group("", authMiddleware))
+    healthHandler.RegisterRoutes(router)
+    verificationHandler.RegisterRoutes(router)
+    resetHandler.RegisterRoutes(router)
+    
+    // Login por senha só existe quando há um segredo HS256 para assinar os tokens
+    if issuer, err := authn.NewTokenIssuer(jwtConfig, cfg.AuthAccessTokenTTL); err == nil {
+        authService := service.NewAuthService(userRepo, tokenRepo, hasher, issuer,
+            service.WithRefreshTokenTTL(cfg.AuthRefreshTokenTTL),
+            service.WithAuthEmailPolicy(emailPolicy),
+            service.WithAuthLogger(logger),
+        )
+        http.NewAuthHandler(authService).RegisterRoutes(router)
+    } else {
+        logger.Warn("password login disabled, AUTH_JWT_SECRET is not set")
+    }
     
-    router := setupRouter()
-    userHandler.RegisterRoutes(router)
+    publisher, err := events.NewPublisher(events.Config{
+        Driver:   events.Driver(cfg.OutboxPublisher),
+        FilePath: cfg.OutboxFile,
+    }, logger)
+    if err != nil {
+        return fmt.Errorf("failed to set up event publisher: %w", err)
+    }
+    
+    // Os jobs param antes do pool fechar: estes defers rodam antes do defer do pool
+    relayCtx, stopRelay := context.WithCancel(ctx)
+    relayDone := make(chan struct{})
+    defer func() {
+        stopRelay()
+        <-relayDone
+    }()
+    // Os webhooks recebem os eventos pelo relay, como qualquer outro consumidor
+    relay := service.NewOutboxRelay(outboxRepo, events.Fanout{publisher, webhookService},
+        service.WithRelayInterval(cfg.OutboxRelayInterval),
+        service.WithRelayRetries(int(cfg.OutboxMaxAttempts), service.DefaultRelayBaseBackoff, service.DefaultRelayMaxBackoff),
+        service.WithRelayLogger(logger),
+    )
+    go func() {
+        defer close(relayDone)
+        relay.Run(relayCtx)
+    }()
+    
+    dispatchCtx, stopDispatch := context.WithCancel(ctx)
+    dispatchDone := make(chan struct{})
+    defer func() {
+        stopDispatch()
+        <-dispatchDone
+    }()
+    dispatcher := service.NewWebhookDispatcher(webhookRepo, deliveryRepo, webhook.NewClient(cfg.WebhookTimeout),
+        service.WithDispatchInterval(cfg.WebhookDispatchInterval),
+        service.WithDispatchRetries(int(cfg.WebhookMaxAttempts), service.DefaultWebhookBaseBackoff, service.DefaultWebhookMaxBackoff),
+        service.WithDispatchLease(time.Duration(service.DefaultWebhookBatchSize)*cfg.WebhookTimeout+time.Minute),
+        service.WithDispatchLogger(logger),
+    )
+    go func() {
+        defer close(dispatchDone)
+        dispatcher.Run(dispatchCtx)
+    }()
+    
+    purgeCtx, stopPurge := context.WithCancel(ctx)
+    purgeDone := make(chan struct{})
+    defer func() {
+        stopPurge()
+        <-purgeDone
+    }()
+    if cfg.UserPurgeRetention > 0 {
+        purgeJob := service.NewPurgeJob(userRepo, cfg.UserPurgeRetention, cfg.UserPurgeInterval, logger)
+        go func() {
+            defer close(purgeDone)
+            purgeJob.Run(purgeCtx)
+        }()
+    } else {
+        close(purgeDone)
+        logger.Info("purge of deleted users is disabled")
+    }
+    
+    server := &nethttp.Server{
+        Addr:              ":" + cfg.Port,
+        Handler:           router,
+        ReadHeaderTimeout: 10 * time.Second,
+    }
+    // Os streams de /users/events não terminam sozinhos e travariam o Shutdown
+    server.RegisterOnShutdown(eventsHandler.Shutdown)
+    
+    serverErr := make(chan error, 1)
+    go func() {
+        logger.Info("server starting", "port", cfg.Port, "env", cfg.Env)
+        logger.Info("swagger docs available", "url", "http://localhost:"+cfg.Port+"/swagger/index.html")
+        if err := server.ListenAndServe(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+            serverErr <- err
+        }
+    }()
+    healthHandler.SetReady(true)
+    
+    select {
+    case err := <-serverErr:
+        return fmt.Errorf("failed to start server: %w", err)
+    case <-ctx.Done():
+    }
+    stop()
+    
+    // Primeiro derruba a readiness para o balanceador parar de mandar tráfego,
+    // depois drena as requisições em andamento. O pool fecha no defer acima.
+    logger.Info("shutdown signal received, marking instance as not ready")
+    healthHandler.SetReady(false)
+    time.Sleep(cfg.ShutdownDrainDelay)
+    
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+    defer cancel()
     
-    log.Printf("Server starting on port %s (env: %s)", cfg.Port, cfg.Env)
-    log.Printf("Swagger docs available at: http://localhost:%s/swagger/index.html", cfg.Port)
-    if err := router.Run(":" + cfg.Port); err != nil {
-        log.Fatal("Failed to start server:", err)
+    logger.Info("draining in-flight requests", "timeout", cfg.ShutdownTimeout.String())
+    err = server.Shutdown(shutdownCtx)
+    // Os emails de reset já respondidos com 202 ainda precisam sair
+    resetService.Wait()
+    if err != nil {
+        return fmt.Errorf("graceful shutdown failed: %w", err)
     }
+    
+    logger.Info("server stopped")
+    return nil
 }
 
 func setupDatabase(cfg *config.Config) (*pgxpool.Pool, error) {
@@ -91,16 +425,85 @@ func setupDatabase(cfg *config.Config) (*pgxpool.Pool, error) {
     return pool, nil
 }
 
-func setupRouter() *gin.Engine {
-    router := gin.Default()
+func loadJWTConfig(cfg *config.Config) (authn.JWTConfig, error) {
+    jwtConfig := authn.JWTConfig{
+        HMACSecret: []byte(cfg.AuthJWTSecret),
+        Issuer:     cfg.AuthJWTIssuer,
+        Audience:   cfg.AuthJWTAudience,
+    }
+    if cfg.AuthJWKSFile != "" {
+        jwks, err := authn.LoadJWKSFile(cfg.AuthJWKSFile)
+        if err != nil {
+            return authn.JWTConfig{}, err
+        }
+        jwtConfig.JWKS = jwks
+    }
+    return jwtConfig, nil
+}
+
+// loadEmailPolicy junta os domínios bloqueados da variável de ambiente com
+// os do arquivo, um por linha; linhas vazias e começando com # são ignoradas.
+func loadEmailPolicy(cfg *config.Config) (entity.EmailPolicy, error) {
+    policy := entity.EmailPolicy{
+        NormalizeProviders: cfg.EmailNormalizeProviders,
+        BlockedDomains:     cfg.EmailBlockedDomains,
+    }
+    if cfg.EmailBlockedDomainsFile != "" {
+        data, err := os.ReadFile(cfg.EmailBlockedDomainsFile)
+        if err != nil {
+            return entity.EmailPolicy{}, err
+        }
+        for _, line := range strings.Split(string(data), "\n") {
+            if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+                policy.BlockedDomains = append(policy.BlockedDomains, line)
+            }
+        }
+    }
+    return policy, nil
+}
+
+func setupAuth(cfg *config.Config, jwtConfig authn.JWTConfig, logger *slog.Logger) (gin.HandlerFunc, error) {
+    if cfg.AuthDisabled {
+        logger.Warn("authentication is disabled, every request runs as admin")
+        return http.AllowAnonymous(&auth.Principal{ID: "anonymous", Roles: []string{auth.RoleAdmin}}), nil
+    }
+    
+    apiKeys, err := authn.ParseAPIKeys(cfg.AuthAPIKeys)
+    if err != nil {
+        return nil, err
+    }
     
-    router.Use(gin.Recovery())
+    jwtVerifier := authn.NewJWTVerifier(jwtConfig)
+    
+    if apiKeys.Empty() && !jwtVerifier.Enabled() {
+        return nil, errors.New("no API keys or JWT keys configured (set AUTH_API_KEYS, AUTH_JWT_SECRET or AUTH_JWKS_FILE, or AUTH_DISABLED=true)")
+    }
+    
+    return http.Authenticate(authn.NewAuthenticator(apiKeys, jwtVerifier)), nil
+}
+
+func setupRouter(appMetrics *metrics.Metrics, catalog *i18n.Catalog, trustedProxies []string, logger *slog.Logger) (*gin.Engine, error) {
+    router := gin.New()
+    
+    // Por padrão o Gin confia no X-Forwarded-For de qualquer um, e o
+    // ClientIP (usado nos limites por IP) seria escolhido pelo cliente
+    if err := router.SetTrustedProxies(trustedProxies); err != nil {
+        return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+    }
+    
+    // Metrics e o log ficam antes do Recovery para enxergar o 500 de um panic
+    router.Use(http.RequestLogger(logger))
+    router.Use(http.Tracing())
+    router.Use(http.Metrics(appMetrics))
+    router.Use(http.Recovery(catalog))
+    router.Use(http.ErrorHandler(catalog))
     
     // CORS para Swagger
     router.Use(func(c *gin.Context) {
         c.Header("Access-Control-Allow-Origin", "*")
-        c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-        c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+        c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+        c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, If-Match, Accept-Language, Last-Event-ID")
+        c.Header("Access-Control-Expose-Headers", "ETag, Link, Accept-Patch, Content-Language")
         
         if c.Request.Method == "OPTIONS" {
             c.AbortWithStatus(204)
@@ -118,22 +521,8 @@ func setupRouter() *gin.Engine {
         ginSwagger.DocExpansion("none"),
     ))
 
-    router.GET("/health", healthCheck)
-    
-    return router
-}
+    router.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+    router.NoRoute(http.RouteNotFound())
 
-// healthCheck godoc
-// @Summary      Health Check
-// @Description  Verifica se a API está funcionando
-// @Tags         health
-// @Accept       json
-// @Produce      json
-// @Success      200  {object}  map[string]string
-// @Router       /health [get]
-func healthCheck(c *gin.Context) {
-    c.JSON(200, gin.H{
-        "status":  "ok",
-        "service": "user-api",
-    })
-}
\ No newline at end of file
+    return router, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/JoaoVitorFerreiro/golang-start/config"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// runMigrate implementa o subcomando `migrate up | down [n] | status`.
+func runMigrate(cfg *config.Config, logger *slog.Logger, args []string) error {
+    command := "up"
+    if len(args) > 0 {
+        command = args[0]
+    }
+    
+    pool, err := setupDatabase(cfg)
+    if err != nil {
+        return fmt.Errorf("connect to database: %w", err)
+    }
+    defer pool.Close()
+    
+    migrator, err := database.NewMigrator(pool)
+    if err != nil {
+        return err
+    }
+    
+    ctx := context.Background()
+    
+    switch command {
+    case "up":
+        applied, err := migrator.Up(ctx)
+        for _, mig := range applied {
+            logger.Info("applied migration", "version", mig.Version, "name", mig.Name)
+        }
+        if err == nil && len(applied) == 0 {
+            logger.Info("database is up to date")
+        }
+        return err
+    case "down":
+        steps := 1
+        if len(args) > 1 {
+            steps, err = strconv.Atoi(args[1])
+            if err != nil || steps < 1 {
+                return fmt.Errorf("invalid number of steps: %s", args[1])
+            }
+        }
+        reverted, err := migrator.Down(ctx, steps)
+        for _, mig := range reverted {
+            logger.Info("reverted migration", "version", mig.Version, "name", mig.Name)
+        }
+        return err
+    case "status":
+        statuses, err := migrator.Status(ctx)
+        if err != nil {
+            return err
+        }
+        for _, s := range statuses {
+            state := "pending"
+            if s.AppliedAt != nil {
+                state = "applied at " + s.AppliedAt.Format("2006-01-02T15:04:05Z07:00")
+            }
+            fmt.Printf("%04d_%s\t%s\n", s.Version, s.Name, state)
+        }
+        return nil
+    default:
+        return fmt.Errorf("unknown migrate command %q (use up, down [n] or status)", command)
+    }
+}
+
+func migrateOnStart(pool *pgxpool.Pool, logger *slog.Logger) error {
+    migrator, err := database.NewMigrator(pool)
+    if err != nil {
+        return err
+    }
+    
+    applied, err := migrator.Up(context.Background())
+    for _, mig := range applied {
+        logger.Info("applied migration", "version", mig.Version, "name", mig.Name)
+    }
+    return err
+}
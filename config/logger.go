@@ -1,45 +0,0 @@
-package config
-
-import (
-	"io"
-	"log"
-	"os"
-)
-
-type Logger struct {
-    debug   *log.Logger
-    info    *log.Logger
-    warning *log.Logger
-    error   *log.Logger
-    writer  io.Writer
-}
-
-func NewLogger(p string) *Logger {
-    writer := io.Writer(os.Stdout)
-    logger := log.New(writer, p, log.Ldate|log.Ltime)
-    
-    return &Logger{
-        debug:   log.New(writer, p+" [DEBUG] ", logger.Flags()),
-        info:    log.New(writer, p+" [INFO] ", logger.Flags()),
-        warning: log.New(writer, p+" [WARNING] ", logger.Flags()),
-        error:   log.New(writer, p+" [ERROR] ", logger.Flags()),
-        writer:  writer,
-    }
-}
-
-// Métodos para usar o logger
-func (l *Logger) Debug(v ...interface{}) {
-    l.debug.Println(v...)
-}
-
-func (l *Logger) Info(v ...interface{}) {
-    l.info.Println(v...)
-}
-
-func (l *Logger) Warning(v ...interface{}) {
-    l.warning.Println(v...)
-}
-
-func (l *Logger) Error(v ...interface{}) {
-    l.error.Println(v...)
-}
\ No newline at end of file
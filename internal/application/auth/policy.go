@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+    RoleAdmin    = "admin"
+    RoleOperator = "operator"
+    RoleSelf     = "self"
+)
+
+type Action string
+
+const (
+    ActionCreateUser  Action = "users:create"
+    ActionReadUser    Action = "users:read"
+    ActionListUsers   Action = "users:list"
+    ActionUpdateUser  Action = "users:update"
+    ActionDeleteUser  Action = "users:delete"
+    // ActionRestoreUser e ActionPurgeUser (exclusão definitiva) são só de admin
+    ActionRestoreUser Action = "users:restore"
+    ActionPurgeUser   Action = "users:purge"
+
+    // ActionManageWebhooks cobre as inscrições e suas entregas; só admin
+    ActionManageWebhooks Action = "webhooks:manage"
+)
+
+var ErrForbidden = errors.New("forbidden")
+
+// ForbiddenError descreve a operação negada. errors.Is(err, ErrForbidden)
+// continua funcionando para quem só quer saber se foi uma negação.
+type ForbiddenError struct {
+    PrincipalID string
+    Action      Action
+}
+
+func (e *ForbiddenError) Error() string {
+    return fmt.Sprintf("%s is not allowed to perform %s", e.PrincipalID, e.Action)
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+    return target == ErrForbidden
+}
+
+// Policy decide se o principal pode executar a ação. ownerID é o dono do
+// recurso alvo, vazio quando a ação não se refere a um usuário específico.
+type Policy interface {
+    Authorize(p *Principal, action Action, ownerID string) error
+}
+
+// RolePolicy implementa as regras padrão:
+//   - admin pode tudo;
+//   - operator cria, lê e atualiza qualquer usuário;
+//   - self (ou sem papel) só lê e atualiza o próprio registro.
+type RolePolicy struct{}
+
+func (RolePolicy) Authorize(p *Principal, action Action, ownerID string) error {
+    if p == nil {
+        return ErrUnauthenticated
+    }
+    if p.HasRole(RoleAdmin) {
+        return nil
+    }
+    
+    switch action {
+    case ActionCreateUser:
+        if p.HasRole(RoleOperator) {
+            return nil
+        }
+    case ActionReadUser, ActionUpdateUser:
+        if p.HasRole(RoleOperator) {
+            return nil
+        }
+        if ownerID != "" && p.ID == ownerID {
+            return nil
+        }
+    }
+    
+    return &ForbiddenError{PrincipalID: p.ID, Action: action}
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRolePolicy_Authorize(t *testing.T) {
+    admin := &Principal{ID: "root", Roles: []string{RoleAdmin}}
+    operator := &Principal{ID: "ops", Roles: []string{RoleOperator}}
+    self := &Principal{ID: "user-1", Roles: []string{RoleSelf}}
+    
+    cases := []struct {
+        name      string
+        principal *Principal
+        action    Action
+        ownerID   string
+        allowed   bool
+    }{
+        {"admin deletes", admin, ActionDeleteUser, "user-1", true},
+        {"admin lists", admin, ActionListUsers, "", true},
+        {"operator reads any", operator, ActionReadUser, "user-1", true},
+        {"operator updates any", operator, ActionUpdateUser, "user-1", true},
+        {"operator cannot delete", operator, ActionDeleteUser, "user-1", false},
+        {"operator cannot list", operator, ActionListUsers, "", false},
+        {"self reads own", self, ActionReadUser, "user-1", true},
+        {"self updates own", self, ActionUpdateUser, "user-1", true},
+        {"self cannot read others", self, ActionReadUser, "user-2", false},
+        {"self cannot update others", self, ActionUpdateUser, "user-2", false},
+        {"self cannot delete own", self, ActionDeleteUser, "user-1", false},
+        {"self cannot list", self, ActionListUsers, "", false},
+        {"self cannot create", self, ActionCreateUser, "", false},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            err := RolePolicy{}.Authorize(tc.principal, tc.action, tc.ownerID)
+            
+            if tc.allowed && err != nil {
+                t.Errorf("Expected to be allowed, got %v", err)
+            }
+            if !tc.allowed && !errors.Is(err, ErrForbidden) {
+                t.Errorf("Expected ErrForbidden, got %v", err)
+            }
+        })
+    }
+}
+
+func TestRolePolicy_Authorize_WithoutPrincipal(t *testing.T) {
+    err := RolePolicy{}.Authorize(nil, ActionReadUser, "user-1")
+    
+    if !errors.Is(err, ErrUnauthenticated) {
+        t.Errorf("Expected ErrUnauthenticated, got %v", err)
+    }
+}
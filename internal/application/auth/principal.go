@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrUnauthenticated = errors.New("authentication required")
+
+type AuthMethod string
+
+const (
+    MethodAPIKey AuthMethod = "api_key"
+    MethodJWT    AuthMethod = "jwt"
+)
+
+// Principal é quem está chamando a API, independente de como se autenticou.
+// Para JWT, ID é o subject do token; para API keys, o nome da chave.
+type Principal struct {
+    ID     string
+    Method AuthMethod
+    Roles  []string
+}
+
+func (p *Principal) HasRole(role string) bool {
+    for _, r := range p.Roles {
+        if r == role {
+            return true
+        }
+    }
+    return false
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+    return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+    p, ok := ctx.Value(principalKey{}).(*Principal)
+    return p, ok && p != nil
+}
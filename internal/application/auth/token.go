@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+    ErrInvalidCredentials  = errors.New("invalid credentials")
+    ErrInvalidRefreshToken = errors.New("invalid refresh token")
+)
+
+// TokenIssuer assina os access tokens entregues no login e no refresh.
+type TokenIssuer interface {
+    Issue(subject string, roles []string) (token string, expiresAt time.Time, err error)
+}
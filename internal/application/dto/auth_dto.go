@@ -0,0 +1,26 @@
+package dto
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email" example:"joao@email.com"`
+	Password string `json:"password" binding:"required" example:"S3nhaForte"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"q4Xr0d2cQ0m3y7Vb1u9wZk8sT5pL6nHj2fG4aE1cB0"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int    `json:"expires_in" example:"900"`
+	RefreshToken string `json:"refresh_token" example:"q4Xr0d2cQ0m3y7Vb1u9wZk8sT5pL6nHj2fG4aE1cB0"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email" example:"joao@email.com"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required" example:"Zx8bq1Tn0c4V7mKp2sR9wL3yH6jD5fA0eU1gI8oQ2tB"`
+	Password string `json:"password" binding:"required" example:"N0vaSenhaForte"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+// ProblemDetails é o corpo de toda resposta de erro, no formato
+// application/problem+json da RFC 7807.
+type ProblemDetails struct {
+	// Type identifica o tipo do problema; o final do caminho é um código estável
+	Type     string `json:"type" example:"/problems/user-not-found"`
+	Title    string `json:"title" example:"User not found"`
+	Status   int    `json:"status" example:"404"`
+	Detail   string `json:"detail,omitempty" example:"Usuário não encontrado"`
+	Instance string `json:"instance,omitempty" example:"/users/550e8400-e29b-41d4-a716-446655440000"`
+	// TraceID liga a resposta aos logs e ao trace da requisição
+	TraceID string       `json:"trace_id,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError aponta um campo inválido para o cliente destacar no formulário.
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Code    string `json:"code" example:"email"`
+	Message string `json:"message" example:"email must be a valid email address"`
+}
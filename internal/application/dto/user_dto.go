@@ -1,24 +1,82 @@
 package dto
 
+import "time"
+
 type CreateUserRequest struct {
-	Name  string `json:"name" binding:"required" example:"João Silva"`
-	Email string `json:"email" binding:"required,email" example:"joao@email.com"`
+	Name     string `json:"name" binding:"required" example:"João Silva"`
+	Email    string `json:"email" binding:"required" example:"joao@email.com"`
+	// Password é opcional; sem ela o usuário não consegue usar /auth/login
+	Password string `json:"password,omitempty" example:"S3nhaForte"`
 }
 
+// UpdateUserRequest é a representação completa usada pelo PUT; para
+// alterar só alguns campos use PATCH.
 type UpdateUserRequest struct {
-	Name  string `json:"name,omitempty" example:"João Santos"`
-	Email string `json:"email,omitempty" example:"joao.santos@email.com"`
+	Name  string `json:"name" binding:"required" example:"João Santos"`
+	Email string `json:"email" binding:"required" example:"joao.santos@email.com"`
+}
+
+// PatchUserRequest carrega o corpo do PATCH sem interpretar; ContentType
+// decide se é JSON Merge Patch ou JSON Patch.
+type PatchUserRequest struct {
+	ContentType string
+	Document    []byte
 }
 
 type UserResponse struct {
-	ID        string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name      string `json:"name" example:"João Silva"`
-	Email     string `json:"email" example:"joao@email.com"`
-	CreatedAt string `json:"created_at" example:"2024-07-08T10:30:00Z"`
-	UpdatedAt string `json:"updated_at" example:"2024-07-08T11:45:00Z"`
+	ID              string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name            string `json:"name" example:"João Silva"`
+	Email           string `json:"email" example:"joao@email.com"`
+	// EmailVerifiedAt só aparece depois que o email foi confirmado
+	EmailVerifiedAt string `json:"email_verified_at,omitempty" example:"2024-07-08T10:35:00Z"`
+	CreatedAt       string `json:"created_at" example:"2024-07-08T10:30:00Z"`
+	UpdatedAt       string `json:"updated_at" example:"2024-07-08T11:45:00Z"`
+	// Version é a mesma do header ETag; muda a cada alteração salva
+	Version         int64  `json:"version" example:"3"`
+}
+
+// UserDeletedNotification é o data do evento user.deleted em /users/events;
+// os demais eventos trazem o UserResponse completo.
+type UserDeletedNotification struct {
+	ID string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// Precondition carrega o If-Match da requisição. Sem o header (ou com "*")
+// não há pré-condição e a operação segue normalmente.
+type Precondition struct {
+	Present bool
+	// IfMatch são as versões aceitas; se Present e vazio, nenhuma serve
+	IfMatch []int64
 }
 
-type ErrorResponse struct {
-	Error   string `json:"error" example:"user not found"`
-	Message string `json:"message,omitempty" example:"O usuário com ID especificado não foi encontrado"`
-}
\ No newline at end of file
+// Matches diz se a versão atual satisfaz a pré-condição.
+func (p Precondition) Matches(version int64) bool {
+	if !p.Present {
+		return true
+	}
+	for _, v := range p.IfMatch {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+type ListUsersQuery struct {
+	Limit         int       `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+	Cursor        string    `form:"cursor"`
+	Sort          string    `form:"sort" binding:"omitempty,oneof=created_at -created_at" example:"-created_at"`
+	NamePrefix    string    `form:"name_prefix" example:"Jo"`
+	EmailPrefix   string    `form:"email_prefix" example:"joao"`
+	CreatedAfter  time.Time `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore time.Time `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type VerifyEmailQuery struct {
+	Token string `form:"token" binding:"required"`
+}
+
+type UserListResponse struct {
+	Data       []*UserResponse `json:"data"`
+	NextCursor string          `json:"next_cursor,omitempty" example:"eyJjIjoiMjAyNC0wNy0wOFQxMDozMDowMFoiLCJpIjoiNTUwZTg0MDAiLCJzIjoiZGVzYyJ9"`
+}
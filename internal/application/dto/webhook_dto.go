@@ -0,0 +1,69 @@
+package dto
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required" example:"https://parceiro.example.com/hooks/users"`
+	// Secret é opcional; sem ele a API gera um, devolvido só nesta resposta
+	Secret      string   `json:"secret,omitempty" example:"whsec_3q2+7w=="`
+	// Events filtra os tipos entregues; vazio recebe todos
+	Events      []string `json:"events" example:"user.created,user.deleted"`
+	Description string   `json:"description,omitempty" example:"CRM do parceiro"`
+}
+
+// UpdateWebhookRequest substitui a configuração (PUT); o segredo não muda.
+type UpdateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required" example:"https://parceiro.example.com/hooks/users"`
+	Events      []string `json:"events" example:"user.created"`
+	Description string   `json:"description,omitempty" example:"CRM do parceiro"`
+	Active      *bool    `json:"active" binding:"required" example:"true"`
+}
+
+type WebhookResponse struct {
+	ID          string   `json:"id" example:"0b7e7c5e-5f2a-4a8e-9a41-6f1d3c2b9e10"`
+	URL         string   `json:"url" example:"https://parceiro.example.com/hooks/users"`
+	Events      []string `json:"events" example:"user.created,user.deleted"`
+	Description string   `json:"description,omitempty" example:"CRM do parceiro"`
+	Active      bool     `json:"active" example:"true"`
+	// Secret só aparece na resposta da criação
+	Secret      string   `json:"secret,omitempty" example:"whsec_3q2+7w=="`
+	CreatedAt   string   `json:"created_at" example:"2024-07-08T10:30:00Z"`
+	UpdatedAt   string   `json:"updated_at" example:"2024-07-08T10:30:00Z"`
+}
+
+type WebhookListResponse struct {
+	Data []*WebhookResponse `json:"data"`
+}
+
+type ListDeliveriesQuery struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+	Cursor string `form:"cursor"`
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded failed" example:"failed"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string `json:"id" example:"5c1f4f0a-3d7b-4b8e-8f0e-1a2b3c4d5e6f"`
+	EventID        string `json:"event_id" example:"e818c8c9-0072-4bc4-9088-b54cae6a8281"`
+	EventType      string `json:"event_type" example:"user.created"`
+	Status         string `json:"status" example:"pending"`
+	Attempts       int    `json:"attempts" example:"2"`
+	// NextAttemptAt só aparece enquanto a entrega está pendente
+	NextAttemptAt  string `json:"next_attempt_at,omitempty" example:"2024-07-08T10:31:00Z"`
+	LastStatusCode int    `json:"last_status_code,omitempty" example:"503"`
+	LastError      string `json:"last_error,omitempty" example:"webhook endpoint responded 503"`
+	CreatedAt      string `json:"created_at" example:"2024-07-08T10:30:00Z"`
+	DeliveredAt    string `json:"delivered_at,omitempty" example:"2024-07-08T10:32:00Z"`
+	// AttemptLog só vem na busca de uma entrega específica
+	AttemptLog     []WebhookAttemptResponse `json:"attempt_log,omitempty"`
+}
+
+type WebhookAttemptResponse struct {
+	Number     int    `json:"number" example:"1"`
+	StatusCode int    `json:"status_code,omitempty" example:"503"`
+	Error      string `json:"error,omitempty" example:"webhook endpoint responded 503"`
+	DurationMs int64  `json:"duration_ms" example:"120"`
+	At         string `json:"at" example:"2024-07-08T10:30:01Z"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Data       []*WebhookDeliveryResponse `json:"data"`
+	NextCursor string                     `json:"next_cursor,omitempty"`
+}
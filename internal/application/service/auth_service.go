@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// AuthService troca email e senha por um par access token (JWT curto) e
+// refresh token (opaco, guardado só como hash, rotacionado a cada uso).
+type AuthService struct {
+    userRepo   repository.UserRepository
+    tokenRepo  repository.RefreshTokenRepository
+    hasher     entity.PasswordHasher
+    emails     entity.EmailPolicy
+    issuer     auth.TokenIssuer
+    refreshTTL time.Duration
+    logger     *slog.Logger
+
+    dummyHash     string
+    dummyHashOnce sync.Once
+}
+
+type AuthOption func(*AuthService)
+
+func WithRefreshTokenTTL(ttl time.Duration) AuthOption {
+    return func(s *AuthService) {
+        s.refreshTTL = ttl
+    }
+}
+
+// WithAuthEmailPolicy deve ser a mesma política do UserService, para que o
+// login encontre o usuário pela mesma forma canônica do cadastro.
+func WithAuthEmailPolicy(policy entity.EmailPolicy) AuthOption {
+    return func(s *AuthService) {
+        s.emails = policy
+    }
+}
+
+func WithAuthLogger(logger *slog.Logger) AuthOption {
+    return func(s *AuthService) {
+        s.logger = logger
+    }
+}
+
+func NewAuthService(
+    userRepo repository.UserRepository,
+    tokenRepo repository.RefreshTokenRepository,
+    hasher entity.PasswordHasher,
+    issuer auth.TokenIssuer,
+    opts ...AuthOption,
+) *AuthService {
+    s := &AuthService{
+        userRepo:   userRepo,
+        tokenRepo:  tokenRepo,
+        hasher:     hasher,
+        emails:     entity.DefaultEmailPolicy,
+        issuer:     issuer,
+        refreshTTL: DefaultRefreshTokenTTL,
+        logger:     slog.Default(),
+    }
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
+}
+
+func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (_ *dto.TokenResponse, err error) {
+    ctx, span := tracer.Start(ctx, "AuthService.Login")
+    defer func() { endSpan(span, err) }()
+
+    var user *entity.User
+    if email, err := s.emails.Parse(req.Email); err == nil {
+        user, err = s.userRepo.FindByEmail(ctx, email.Canonical())
+        if err != nil {
+            return nil, err
+        }
+    }
+    if user == nil || !user.HasPassword() {
+        // Confere contra um hash descartável para que um email inexistente
+        // leve o mesmo tempo que uma senha errada.
+        _, _ = s.hasher.Verify(s.getDummyHash(), req.Password)
+        return nil, auth.ErrInvalidCredentials
+    }
+
+    ok, err := user.CheckPassword(req.Password, s.hasher)
+    if err != nil {
+        return nil, err
+    }
+    if !ok {
+        s.logger.InfoContext(ctx, "login failed", "user_id", user.ID)
+        return nil, auth.ErrInvalidCredentials
+    }
+
+    response, err := s.issueTokens(ctx, user)
+    if err != nil {
+        return nil, err
+    }
+
+    s.logger.InfoContext(ctx, "user logged in", "user_id", user.ID)
+    return response, nil
+}
+
+func (s *AuthService) Refresh(ctx context.Context, req dto.RefreshTokenRequest) (_ *dto.TokenResponse, err error) {
+    ctx, span := tracer.Start(ctx, "AuthService.Refresh")
+    defer func() { endSpan(span, err) }()
+
+    tokenHash := hashToken(req.RefreshToken)
+    now := time.Now()
+    token, err := s.tokenRepo.Consume(ctx, tokenHash, now)
+    if err != nil {
+        return nil, err
+    }
+    if token == nil {
+        return nil, s.rejectRefresh(ctx, tokenHash, now)
+    }
+
+    user, err := s.userRepo.FindByID(ctx, token.UserID)
+    if err != nil {
+        return nil, err
+    }
+    if user == nil {
+        return nil, auth.ErrInvalidRefreshToken
+    }
+
+    return s.issueTokens(ctx, user)
+}
+
+// rejectRefresh decide por que o token não pôde ser consumido. Se ele existe
+// e já foi revogado, um token rotacionado voltou (ou perdeu a corrida para
+// outra requisição com o mesmo valor): alguém além do dono o tem, então
+// todas as sessões do usuário caem.
+func (s *AuthService) rejectRefresh(ctx context.Context, tokenHash string, now time.Time) error {
+    token, err := s.tokenRepo.FindByHash(ctx, tokenHash)
+    if err != nil {
+        return err
+    }
+    if token != nil && token.Revoked() {
+        s.logger.WarnContext(ctx, "refresh token reuse detected, revoking all sessions", "user_id", token.UserID)
+        if err := s.tokenRepo.RevokeAllForUser(ctx, token.UserID, now); err != nil {
+            return err
+        }
+    }
+    return auth.ErrInvalidRefreshToken
+}
+
+// Logout revoga o refresh token informado. Tokens desconhecidos ou já
+// revogados não são erro, para que a chamada seja idempotente.
+func (s *AuthService) Logout(ctx context.Context, req dto.RefreshTokenRequest) (err error) {
+    ctx, span := tracer.Start(ctx, "AuthService.Logout")
+    defer func() { endSpan(span, err) }()
+
+    token, err := s.tokenRepo.Consume(ctx, hashToken(req.RefreshToken), time.Now())
+    if err != nil {
+        return err
+    }
+    if token == nil {
+        return nil
+    }
+
+    s.logger.InfoContext(ctx, "user logged out", "user_id", token.UserID)
+    return nil
+}
+
+func (s *AuthService) issueTokens(ctx context.Context, user *entity.User) (*dto.TokenResponse, error) {
+    accessToken, expiresAt, err := s.issuer.Issue(user.ID, []string{auth.RoleSelf})
+    if err != nil {
+        return nil, err
+    }
+
+    refreshToken, err := generateToken()
+    if err != nil {
+        return nil, err
+    }
+    if err := s.tokenRepo.Save(ctx, entity.NewRefreshToken(user.ID, hashToken(refreshToken), s.refreshTTL)); err != nil {
+        return nil, err
+    }
+
+    return &dto.TokenResponse{
+        AccessToken:  accessToken,
+        TokenType:    "Bearer",
+        ExpiresIn:    int(time.Until(expiresAt).Round(time.Second).Seconds()),
+        RefreshToken: refreshToken,
+    }, nil
+}
+
+func (s *AuthService) getDummyHash() string {
+    s.dummyHashOnce.Do(func() {
+        s.dummyHash, _ = s.hasher.Hash("dummy-password-for-timing")
+    })
+    return s.dummyHash
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+type fakeIssuer struct{}
+
+func (fakeIssuer) Issue(subject string, roles []string) (string, time.Time, error) {
+    return "access-" + subject, time.Now().Add(time.Minute), nil
+}
+
+func newAuthTestServices(t *testing.T) (*AuthService, *dto.UserResponse) {
+    t.Helper()
+    
+    userRepo := repository.NewUserRepository(repository.InMemory, nil)
+    tokenRepo := repository.NewRefreshTokenRepository(repository.InMemory, nil)
+    userService := NewUserService(userRepo)
+    
+    user, err := userService.CreateUser(adminContext(), dto.CreateUserRequest{
+        Name:     "João Silva",
+        Email:    "joao@email.com",
+        Password: "S3nhaForte",
+    })
+    if err != nil {
+        t.Fatalf("Expected no error creating user, got %v", err)
+    }
+    
+    return NewAuthService(userRepo, tokenRepo, userService.hasher, fakeIssuer{}), user
+}
+
+func TestAuthService_Login(t *testing.T) {
+    // Arrange
+    service, user := newAuthTestServices(t)
+    ctx := context.Background()
+    
+    // Act
+    tokens, err := service.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "S3nhaForte"})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if tokens.AccessToken != "access-"+user.ID {
+        t.Errorf("Expected access token for %s, got %s", user.ID, tokens.AccessToken)
+    }
+    if tokens.RefreshToken == "" || tokens.TokenType != "Bearer" {
+        t.Errorf("Unexpected token response %+v", tokens)
+    }
+}
+
+func TestAuthService_Login_EmailIgnoresCase(t *testing.T) {
+    // Arrange
+    service, user := newAuthTestServices(t)
+    
+    // Act
+    tokens, err := service.Login(context.Background(), dto.LoginRequest{Email: " JOAO@Email.com", Password: "S3nhaForte"})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if tokens.AccessToken != "access-"+user.ID {
+        t.Errorf("Expected access token for %s, got %s", user.ID, tokens.AccessToken)
+    }
+}
+
+func TestAuthService_Login_InvalidCredentials(t *testing.T) {
+    service, _ := newAuthTestServices(t)
+    ctx := context.Background()
+    
+    cases := map[string]dto.LoginRequest{
+        "wrong password": {Email: "joao@email.com", Password: "Errada123"},
+        "unknown email":  {Email: "maria@email.com", Password: "S3nhaForte"},
+    }
+    for name, req := range cases {
+        if _, err := service.Login(ctx, req); !errors.Is(err, auth.ErrInvalidCredentials) {
+            t.Errorf("%s: expected ErrInvalidCredentials, got %v", name, err)
+        }
+    }
+}
+
+func TestAuthService_Refresh_RotatesAndDetectsReuse(t *testing.T) {
+    // Arrange
+    service, _ := newAuthTestServices(t)
+    ctx := context.Background()
+    
+    first, err := service.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "S3nhaForte"})
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    // Act
+    second, err := service.Refresh(ctx, dto.RefreshTokenRequest{RefreshToken: first.RefreshToken})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected refresh to succeed, got %v", err)
+    }
+    if second.RefreshToken == first.RefreshToken {
+        t.Error("Expected a new refresh token")
+    }
+    
+    // Reusar o token rotacionado revoga também o token novo
+    if _, err := service.Refresh(ctx, dto.RefreshTokenRequest{RefreshToken: first.RefreshToken}); !errors.Is(err, auth.ErrInvalidRefreshToken) {
+        t.Errorf("Expected ErrInvalidRefreshToken on reuse, got %v", err)
+    }
+    if _, err := service.Refresh(ctx, dto.RefreshTokenRequest{RefreshToken: second.RefreshToken}); !errors.Is(err, auth.ErrInvalidRefreshToken) {
+        t.Errorf("Expected every session to be revoked after reuse, got %v", err)
+    }
+}
+
+func TestAuthService_Refresh_ConcurrentRotationSucceedsOnce(t *testing.T) {
+    // Arrange
+    service, _ := newAuthTestServices(t)
+    ctx := context.Background()
+    
+    tokens, err := service.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "S3nhaForte"})
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    const callers = 10
+    var wg sync.WaitGroup
+    errs := make(chan error, callers)
+    start := make(chan struct{})
+    
+    // Act
+    for i := 0; i < callers; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            <-start
+            _, err := service.Refresh(ctx, dto.RefreshTokenRequest{RefreshToken: tokens.RefreshToken})
+            errs <- err
+        }()
+    }
+    close(start)
+    wg.Wait()
+    close(errs)
+    
+    // Assert
+    succeeded := 0
+    for err := range errs {
+        switch {
+        case err == nil:
+            succeeded++
+        case !errors.Is(err, auth.ErrInvalidRefreshToken):
+            t.Errorf("Expected ErrInvalidRefreshToken, got %v", err)
+        }
+    }
+    if succeeded != 1 {
+        t.Errorf("Expected exactly one refresh to succeed, got %d", succeeded)
+    }
+}
+
+func TestAuthService_Logout(t *testing.T) {
+    // Arrange
+    service, _ := newAuthTestServices(t)
+    ctx := context.Background()
+    
+    tokens, _ := service.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "S3nhaForte"})
+    
+    // Act
+    err := service.Logout(ctx, dto.RefreshTokenRequest{RefreshToken: tokens.RefreshToken})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if _, err := service.Refresh(ctx, dto.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}); !errors.Is(err, auth.ErrInvalidRefreshToken) {
+        t.Errorf("Expected ErrInvalidRefreshToken after logout, got %v", err)
+    }
+    if err := service.Logout(ctx, dto.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}); err != nil {
+        t.Errorf("Expected logout to be idempotent, got %v", err)
+    }
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/mail"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+const DefaultEmailVerificationTTL = 24 * time.Hour
+
+// EmailVerificationService envia e confirma os links de verificação de email.
+// Quem decide quando enviar é o UserService (criação, troca de email e
+// reenvio a pedido).
+type EmailVerificationService struct {
+    tokenRepo repository.OneTimeTokenRepository
+    tx        repository.TxManager
+    mailer    mail.Mailer
+    verifyURL string
+    ttl       time.Duration
+    logger    *slog.Logger
+}
+
+type EmailVerificationOption func(*EmailVerificationService)
+
+func WithVerificationTTL(ttl time.Duration) EmailVerificationOption {
+    return func(s *EmailVerificationService) {
+        s.ttl = ttl
+    }
+}
+
+// WithVerificationTxManager define a transação em que Verify consome o
+// token e grava o usuário. Sem ela, os repositórios precisam ser os em
+// memória.
+func WithVerificationTxManager(tx repository.TxManager) EmailVerificationOption {
+    return func(s *EmailVerificationService) {
+        s.tx = tx
+    }
+}
+
+func WithVerificationLogger(logger *slog.Logger) EmailVerificationOption {
+    return func(s *EmailVerificationService) {
+        s.logger = logger
+    }
+}
+
+// NewEmailVerificationService recebe em verifyURL o endereço público de
+// GET /verify; o token é anexado como query string.
+func NewEmailVerificationService(
+    userRepo repository.UserRepository,
+    tokenRepo repository.OneTimeTokenRepository,
+    mailer mail.Mailer,
+    verifyURL string,
+    opts ...EmailVerificationOption,
+) *EmailVerificationService {
+    s := &EmailVerificationService{
+        tokenRepo: tokenRepo,
+        mailer:    mailer,
+        verifyURL: verifyURL,
+        ttl:       DefaultEmailVerificationTTL,
+        logger:    slog.Default(),
+    }
+    for _, opt := range opts {
+        opt(s)
+    }
+    if s.tx == nil {
+        s.tx = repository.NewInMemoryTxManager(repository.Repositories{
+            Users:         userRepo,
+            Outbox:        repository.NewInMemoryOutboxRepository(),
+            OneTimeTokens: tokenRepo,
+        })
+    }
+    return s
+}
+
+// Send invalida os links anteriores do usuário e envia um novo para o
+// email atual.
+func (s *EmailVerificationService) Send(ctx context.Context, user *entity.User) (err error) {
+    ctx, span := tracer.Start(ctx, "EmailVerificationService.Send")
+    defer func() { endSpan(span, err) }()
+
+    if user.IsEmailVerified() {
+        return entity.ErrEmailAlreadyVerified
+    }
+
+    if err := s.tokenRepo.InvalidateForUser(ctx, user.ID, entity.PurposeEmailVerification, time.Now()); err != nil {
+        return err
+    }
+
+    token, err := generateToken()
+    if err != nil {
+        return err
+    }
+    record := entity.NewOneTimeToken(user.ID, entity.PurposeEmailVerification, hashToken(token), user.NormalizedEmail, s.ttl)
+    if err := s.tokenRepo.Save(ctx, record); err != nil {
+        return err
+    }
+
+    link := s.verifyURL + "?" + url.Values{"token": {token}}.Encode()
+    msg := mail.Message{
+        To:      user.Email,
+        Subject: "Confirme seu email",
+        Body: fmt.Sprintf("Olá %s,\n\nConfirme seu email acessando o link abaixo:\n\n%s\n\n"+
+            "O link expira em %s e só pode ser usado uma vez. Se você não criou esta conta, ignore este email.\n",
+            user.Name, link, formatTTL(s.ttl)),
+    }
+    if err := s.mailer.Send(ctx, msg); err != nil {
+        return fmt.Errorf("send verification email: %w", err)
+    }
+
+    s.logger.InfoContext(ctx, "verification email sent", "user_id", user.ID)
+    return nil
+}
+
+// Verify consome o token e marca o email como verificado, numa única
+// transação: se a gravação falhar, o token continua valendo. O token só vale
+// enquanto a forma canônica do email for a mesma para a qual foi enviado.
+func (s *EmailVerificationService) Verify(ctx context.Context, token string) (_ *dto.UserResponse, err error) {
+    ctx, span := tracer.Start(ctx, "EmailVerificationService.Verify")
+    defer func() { endSpan(span, err) }()
+
+    now := time.Now()
+    var user *entity.User
+    err = s.tx.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+        record, err := repos.OneTimeTokens.Consume(ctx, entity.PurposeEmailVerification, hashToken(token), now)
+        if err != nil {
+            return err
+        }
+        if record == nil {
+            return entity.ErrInvalidToken
+        }
+
+        found, err := repos.Users.FindByID(ctx, record.UserID)
+        if err != nil {
+            return err
+        }
+        if found == nil || found.NormalizedEmail != record.Email {
+            return entity.ErrInvalidToken
+        }
+
+        if err := found.MarkEmailVerified(now); err != nil {
+            return err
+        }
+        if err := repos.Users.Save(ctx, found); err != nil {
+            return err
+        }
+        user = found
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    s.logger.InfoContext(ctx, "email verified", "user_id", user.ID)
+    return toUserResponse(user), nil
+}
+
+// formatTTL escreve a validade do link para o texto do email.
+func formatTTL(ttl time.Duration) string {
+    if ttl >= time.Hour && ttl%time.Hour == 0 {
+        return fmt.Sprintf("%d horas", int(ttl.Hours()))
+    }
+    return fmt.Sprintf("%d minutos", int(ttl.Minutes()))
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/mail"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+// recordingMailer guarda as mensagens em memória para os testes lerem o link.
+type recordingMailer struct {
+    mutex    sync.Mutex
+    messages []mail.Message
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mail.Message) error {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.messages = append(m.messages, msg)
+    return nil
+}
+
+// lastToken extrai o token do último link enviado.
+func (m *recordingMailer) lastToken(t *testing.T) string {
+    t.Helper()
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    
+    if len(m.messages) == 0 {
+        t.Fatal("Expected an email to be sent")
+    }
+    body := m.messages[len(m.messages)-1].Body
+    for _, field := range strings.Fields(body) {
+        if u, err := url.Parse(field); err == nil && u.Query().Get("token") != "" {
+            return u.Query().Get("token")
+        }
+    }
+    t.Fatalf("No verification link in %q", body)
+    return ""
+}
+
+func newVerificationTestServices() (*UserService, *EmailVerificationService, *recordingMailer) {
+    userRepo := repository.NewUserRepository(repository.InMemory, nil)
+    tokenRepo := repository.NewOneTimeTokenRepository(repository.InMemory, nil)
+    mailer := &recordingMailer{}
+    
+    verifier := NewEmailVerificationService(userRepo, tokenRepo, mailer, "http://localhost:8080/verify")
+    userService := NewUserService(userRepo, WithEmailVerification(verifier))
+    return userService, verifier, mailer
+}
+
+func TestEmailVerification_CreateUserSendsSingleUseLink(t *testing.T) {
+    // Arrange
+    userService, verifier, mailer := newVerificationTestServices()
+    ctx := adminContext()
+    
+    user, err := userService.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if user.EmailVerifiedAt != "" {
+        t.Fatal("Expected new user to be unverified")
+    }
+    token := mailer.lastToken(t)
+    
+    // Act
+    verified, err := verifier.Verify(context.Background(), token)
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if verified.EmailVerifiedAt == "" {
+        t.Error("Expected email_verified_at to be set")
+    }
+    if _, err := verifier.Verify(context.Background(), token); !errors.Is(err, entity.ErrInvalidToken) {
+        t.Errorf("Expected ErrInvalidToken on second use, got %v", err)
+    }
+}
+
+func TestEmailVerification_ResendInvalidatesPreviousLink(t *testing.T) {
+    // Arrange
+    userService, verifier, mailer := newVerificationTestServices()
+    ctx := adminContext()
+    
+    user, _ := userService.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    first := mailer.lastToken(t)
+    
+    // Act
+    err := userService.RequestEmailVerification(ctx, user.ID)
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if _, err := verifier.Verify(context.Background(), first); !errors.Is(err, entity.ErrInvalidToken) {
+        t.Errorf("Expected previous link to be invalid, got %v", err)
+    }
+    if _, err := verifier.Verify(context.Background(), mailer.lastToken(t)); err != nil {
+        t.Errorf("Expected latest link to work, got %v", err)
+    }
+    if err := userService.RequestEmailVerification(ctx, user.ID); !errors.Is(err, entity.ErrEmailAlreadyVerified) {
+        t.Errorf("Expected ErrEmailAlreadyVerified, got %v", err)
+    }
+}
+
+func TestEmailVerification_EmailChangeResetsVerification(t *testing.T) {
+    // Arrange
+    userService, verifier, mailer := newVerificationTestServices()
+    ctx := adminContext()
+    
+    user, _ := userService.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    if _, err := verifier.Verify(context.Background(), mailer.lastToken(t)); err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    // Act
+    updated, err := userService.UpdateUser(ctx, user.ID, dto.UpdateUserRequest{Name: "João Silva", Email: "joao.novo@email.com"}, dto.Precondition{})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if updated.EmailVerifiedAt != "" {
+        t.Error("Expected verification to be reset after email change")
+    }
+    if len(mailer.messages) != 2 || mailer.messages[1].To != "joao.novo@email.com" {
+        t.Errorf("Expected a new link sent to the new address, got %+v", mailer.messages)
+    }
+}
+
+func TestEmailVerification_CaseOnlyEmailEditKeepsLink(t *testing.T) {
+    // Arrange
+    userService, verifier, mailer := newVerificationTestServices()
+    ctx := adminContext()
+    
+    user, _ := userService.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    token := mailer.lastToken(t)
+    if _, err := userService.UpdateUser(ctx, user.ID, dto.UpdateUserRequest{Name: "João Silva", Email: "Joao@email.com"}, dto.Precondition{}); err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    // Act
+    verified, err := verifier.Verify(context.Background(), token)
+    
+    // Assert: é o mesmo endereço, então o link enviado antes continua valendo
+    if err != nil {
+        t.Fatalf("Expected the link to survive a case-only edit, got %v", err)
+    }
+    if verified.Email != "Joao@email.com" || verified.EmailVerifiedAt == "" {
+        t.Errorf("Expected the edited address to be verified, got %+v", verified)
+    }
+}
+
+// failingSave faz Save falhar enquanto fail for true.
+type failingSave struct {
+    repository.UserRepository
+    fail *bool
+}
+
+func (r failingSave) Save(ctx context.Context, user *entity.User) error {
+    if *r.fail {
+        return errors.New("save failed")
+    }
+    return r.UserRepository.Save(ctx, user)
+}
+
+func TestEmailVerification_FailedSaveKeepsToken(t *testing.T) {
+    // Arrange
+    userRepo := repository.NewUserRepository(repository.InMemory, nil)
+    tokenRepo := repository.NewOneTimeTokenRepository(repository.InMemory, nil)
+    mailer := &recordingMailer{}
+    fail := true
+    tx := repository.NewInMemoryTxManager(repository.Repositories{
+        Users:         userRepo,
+        Outbox:        repository.NewInMemoryOutboxRepository(),
+        OneTimeTokens: tokenRepo,
+    }, repository.WithTxDecorator(func(repos repository.Repositories) repository.Repositories {
+        repos.Users = failingSave{UserRepository: repos.Users, fail: &fail}
+        return repos
+    }))
+    verifier := NewEmailVerificationService(userRepo, tokenRepo, mailer, "http://localhost:8080/verify", WithVerificationTxManager(tx))
+    userService := NewUserService(userRepo, WithEmailVerification(verifier))
+    
+    user, _ := userService.CreateUser(adminContext(), dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    token := mailer.lastToken(t)
+    
+    // Act
+    _, err := verifier.Verify(context.Background(), token)
+    
+    // Assert
+    if err == nil {
+        t.Fatal("Expected the save failure to be returned")
+    }
+    if stored, _ := userRepo.FindByID(context.Background(), user.ID); stored.IsEmailVerified() {
+        t.Error("Expected the email to remain unverified")
+    }
+    // O token não foi queimado: com o Save de volta, a mesma chamada passa
+    fail = false
+    if _, err := verifier.Verify(context.Background(), token); err != nil {
+        t.Errorf("Expected the token to remain usable, got %v", err)
+    }
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/events"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+const (
+    DefaultRelayInterval    = time.Second
+    DefaultRelayBatchSize   = 100
+    DefaultRelayMaxAttempts = 10
+    DefaultRelayBaseBackoff = time.Second
+    DefaultRelayMaxBackoff  = 10 * time.Minute
+)
+
+// OutboxRelay entrega as mensagens do outbox ao Publisher. Uma falha é
+// repetida com espera exponencial; depois de maxAttempts, a mensagem vai
+// para a fila de mensagens mortas (status dead) e para de ser tentada.
+type OutboxRelay struct {
+    outbox      repository.OutboxRepository
+    publisher   events.Publisher
+    interval    time.Duration
+    batchSize   int
+    maxAttempts int
+    baseBackoff time.Duration
+    maxBackoff  time.Duration
+    lease       time.Duration
+    logger      *slog.Logger
+}
+
+type RelayOption func(*OutboxRelay)
+
+func WithRelayInterval(interval time.Duration) RelayOption {
+    return func(r *OutboxRelay) {
+        r.interval = interval
+    }
+}
+
+func WithRelayBatchSize(size int) RelayOption {
+    return func(r *OutboxRelay) {
+        r.batchSize = size
+    }
+}
+
+// WithRelayRetries define quantas tentativas cada mensagem tem e a espera
+// entre elas, que dobra a cada falha a partir de base até maxBackoff.
+func WithRelayRetries(maxAttempts int, base, maxBackoff time.Duration) RelayOption {
+    return func(r *OutboxRelay) {
+        r.maxAttempts = maxAttempts
+        r.baseBackoff = base
+        r.maxBackoff = maxBackoff
+    }
+}
+
+func WithRelayLogger(logger *slog.Logger) RelayOption {
+    return func(r *OutboxRelay) {
+        r.logger = logger
+    }
+}
+
+func NewOutboxRelay(outbox repository.OutboxRepository, publisher events.Publisher, opts ...RelayOption) *OutboxRelay {
+    r := &OutboxRelay{
+        outbox:      outbox,
+        publisher:   publisher,
+        interval:    DefaultRelayInterval,
+        batchSize:   DefaultRelayBatchSize,
+        maxAttempts: DefaultRelayMaxAttempts,
+        baseBackoff: DefaultRelayBaseBackoff,
+        maxBackoff:  DefaultRelayMaxBackoff,
+        logger:      slog.Default(),
+    }
+    for _, opt := range opts {
+        opt(r)
+    }
+    if r.interval <= 0 {
+        r.interval = DefaultRelayInterval
+    }
+    // A reserva precisa durar mais que uma rodada de entregas
+    r.lease = max(time.Minute, 10*r.interval)
+    return r
+}
+
+// Run entrega o que estiver pendente e repete a cada intervalo até o
+// contexto ser cancelado. Enquanto houver lotes cheios, não espera.
+func (r *OutboxRelay) Run(ctx context.Context) {
+    ticker := time.NewTicker(r.interval)
+    defer ticker.Stop()
+
+    for {
+        claimed, err := r.RelayOnce(ctx)
+        if err != nil && ctx.Err() == nil {
+            r.logger.ErrorContext(ctx, "failed to relay outbox messages", "error", err)
+        }
+        if err == nil && claimed == r.batchSize {
+            continue
+        }
+
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+// RelayOnce reserva um lote e tenta entregar cada mensagem. Devolve quantas
+// mensagens foram reservadas.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (_ int, err error) {
+    ctx, span := tracer.Start(ctx, "OutboxRelay.RelayOnce")
+    defer func() { endSpan(span, err) }()
+
+    messages, err := r.outbox.ClaimDue(ctx, time.Now(), r.lease, r.batchSize)
+    if err != nil {
+        return 0, err
+    }
+
+    for _, m := range messages {
+        r.deliver(ctx, m)
+        // Mesmo sem conseguir registrar o resultado, a reserva expira e a
+        // mensagem volta: no pior caso, é entregue de novo
+        if err := r.outbox.Update(ctx, m); err != nil {
+            return len(messages), err
+        }
+    }
+    return len(messages), nil
+}
+
+func (r *OutboxRelay) deliver(ctx context.Context, m *entity.OutboxMessage) {
+    err := r.publisher.Publish(ctx, events.Message{
+        ID:          m.ID,
+        Type:        m.EventType,
+        AggregateID: m.AggregateID,
+        OccurredAt:  m.OccurredAt,
+        Data:        json.RawMessage(m.Payload),
+    })
+    now := time.Now()
+    switch {
+    case err == nil:
+        m.MarkDelivered(now)
+    case m.Attempts+1 >= r.maxAttempts:
+        m.MarkDead(err)
+        r.logger.ErrorContext(ctx, "outbox message moved to dead letter",
+            "event_id", m.ID, "event_type", m.EventType, "attempts", m.Attempts, "error", err)
+    default:
+        m.MarkFailed(err, now.Add(r.backoff(m.Attempts+1)))
+        r.logger.WarnContext(ctx, "failed to publish outbox message",
+            "event_id", m.ID, "event_type", m.EventType, "attempts", m.Attempts, "error", err)
+    }
+}
+
+// backoff devolve a espera depois de uma falha na tentativa número attempt
+// (começando em 1).
+func (r *OutboxRelay) backoff(attempt int) time.Duration {
+    delay := r.baseBackoff
+    for i := 1; i < attempt && delay < r.maxBackoff; i++ {
+        delay *= 2
+    }
+    return min(delay, r.maxBackoff)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/events"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+type fakePublisher struct {
+    published []events.Message
+    err       error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, msg events.Message) error {
+    p.published = append(p.published, msg)
+    return p.err
+}
+
+func TestUserService_RecordsLifecycleEventsInOutbox(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    outbox := repository.NewInMemoryOutboxRepository()
+    service := NewUserService(repo,
+        WithTxManager(repository.NewInMemoryTxManager(repository.Repositories{Users: repo, Outbox: outbox})),
+    )
+    ctx := adminContext()
+    
+    // Act
+    user, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    _, _ = service.UpdateUser(ctx, user.ID, dto.UpdateUserRequest{Name: "João Santos", Email: "joao@email.com"}, dto.Precondition{})
+    _, _ = service.UpdateUser(ctx, user.ID, dto.UpdateUserRequest{Name: "João Santos", Email: "joao.santos@email.com"}, dto.Precondition{})
+    _ = service.DeleteUser(ctx, user.ID, dto.Precondition{})
+    
+    // Assert
+    messages, _ := outbox.ClaimDue(context.Background(), time.Now(), time.Minute, 10)
+    var types []string
+    for _, m := range messages {
+        types = append(types, m.EventType)
+        if m.AggregateID != user.ID {
+            t.Errorf("Expected aggregate %s, got %s", user.ID, m.AggregateID)
+        }
+    }
+    want := []string{entity.EventUserCreated, entity.EventUserEmailChanged, entity.EventUserDeleted}
+    if len(types) != len(want) || types[0] != want[0] || types[1] != want[1] || types[2] != want[2] {
+        t.Fatalf("Expected events %v, got %v", want, types)
+    }
+    if payload := string(messages[1].Payload); payload == "" ||
+        !containsAll(payload, `"old_email":"joao@email.com"`, `"new_email":"joao.santos@email.com"`) {
+        t.Errorf("Unexpected email change payload %s", payload)
+    }
+}
+
+func TestUserService_PurgeRecordsDeletionOnlyForActiveUsers(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    outbox := repository.NewInMemoryOutboxRepository()
+    service := NewUserService(repo,
+        WithTxManager(repository.NewInMemoryTxManager(repository.Repositories{Users: repo, Outbox: outbox})),
+    )
+    ctx := adminContext()
+    
+    active, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    deleted, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "Maria Santos", Email: "maria@email.com"})
+    _ = service.DeleteUser(ctx, deleted.ID, dto.Precondition{})
+    // Tira da fila os eventos até aqui, para sobrarem só os do expurgo
+    _, _ = outbox.ClaimDue(context.Background(), time.Now(), time.Hour, 10)
+    
+    // Act
+    errActive := service.PurgeUser(ctx, active.ID, dto.Precondition{})
+    errDeleted := service.PurgeUser(ctx, deleted.ID, dto.Precondition{})
+    
+    // Assert
+    if errActive != nil || errDeleted != nil {
+        t.Fatalf("Expected no errors, got %v and %v", errActive, errDeleted)
+    }
+    messages, _ := outbox.ClaimDue(context.Background(), time.Now(), time.Minute, 10)
+    if len(messages) != 1 || messages[0].EventType != entity.EventUserDeleted || messages[0].AggregateID != active.ID {
+        t.Fatalf("Expected a single user.deleted for the active user, got %+v", messages)
+    }
+    if stored, _ := repo.FindDeletedByID(context.Background(), active.ID); stored != nil {
+        t.Error("Expected the active user to be purged")
+    }
+}
+
+func TestOutboxRelay_DeliversEachMessageOnce(t *testing.T) {
+    // Arrange
+    outbox := repository.NewInMemoryOutboxRepository()
+    message, _ := entity.NewOutboxMessage(entity.UserDeleted{UserID: "user-1", At: time.Now()})
+    _ = outbox.Add(context.Background(), message)
+    publisher := &fakePublisher{}
+    relay := NewOutboxRelay(outbox, publisher)
+    
+    // Act
+    first, errFirst := relay.RelayOnce(context.Background())
+    second, errSecond := relay.RelayOnce(context.Background())
+    
+    // Assert
+    if errFirst != nil || errSecond != nil {
+        t.Fatalf("Expected no errors, got %v and %v", errFirst, errSecond)
+    }
+    if first != 1 || second != 0 {
+        t.Errorf("Expected to claim 1 then 0 messages, got %d then %d", first, second)
+    }
+    if len(publisher.published) != 1 || publisher.published[0].ID != message.ID || publisher.published[0].Type != entity.EventUserDeleted {
+        t.Errorf("Expected message %s published once, got %+v", message.ID, publisher.published)
+    }
+}
+
+func TestOutboxRelay_RetriesThenDeadLetters(t *testing.T) {
+    // Arrange
+    outbox := repository.NewInMemoryOutboxRepository()
+    message, _ := entity.NewOutboxMessage(entity.UserDeleted{UserID: "user-1", At: time.Now()})
+    _ = outbox.Add(context.Background(), message)
+    publisher := &fakePublisher{err: errors.New("broker indisponível")}
+    relay := NewOutboxRelay(outbox, publisher, WithRelayRetries(3, 0, 0))
+    
+    // Act
+    var claimed []int
+    for i := 0; i < 4; i++ {
+        n, err := relay.RelayOnce(context.Background())
+        if err != nil {
+            t.Fatalf("Expected no error, got %v", err)
+        }
+        claimed = append(claimed, n)
+    }
+    
+    // Assert
+    if len(publisher.published) != 3 {
+        t.Errorf("Expected 3 attempts, got %d", len(publisher.published))
+    }
+    if claimed[3] != 0 {
+        t.Errorf("Expected dead message not to be claimed again, got %v", claimed)
+    }
+}
+
+func TestOutboxRelay_BackoffDoublesUpToMax(t *testing.T) {
+    relay := NewOutboxRelay(nil, nil, WithRelayRetries(10, time.Second, 5*time.Second))
+    
+    for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 9: 5 * time.Second} {
+        if got := relay.backoff(attempt); got != want {
+            t.Errorf("backoff(%d): expected %s, got %s", attempt, want, got)
+        }
+    }
+}
+
+func containsAll(s string, parts ...string) bool {
+    for _, part := range parts {
+        if !strings.Contains(s, part) {
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/mail"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/ratelimit"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+const (
+    DefaultPasswordResetTTL       = time.Hour
+    DefaultPasswordResetEmailRate = 3
+    DefaultPasswordResetIPRate    = 20
+    DefaultPasswordResetWindow    = time.Hour
+)
+
+// PasswordResetService implementa o "esqueci minha senha": envia um token de
+// uso único por email e, ao consumi-lo, troca a senha e derruba as sessões.
+type PasswordResetService struct {
+    userRepo         repository.UserRepository
+    oneTimeTokenRepo repository.OneTimeTokenRepository
+    refreshTokenRepo repository.RefreshTokenRepository
+    tx               repository.TxManager
+    hasher           entity.PasswordHasher
+    emails           entity.EmailPolicy
+    mailer           mail.Mailer
+    resetURL         string
+    ttl              time.Duration
+    emailLimiter     *ratelimit.Limiter
+    ipLimiter        *ratelimit.Limiter
+    logger           *slog.Logger
+    // pending acompanha os envios em segundo plano (veja RequestReset)
+    pending sync.WaitGroup
+}
+
+type PasswordResetOption func(*PasswordResetService)
+
+func WithResetTTL(ttl time.Duration) PasswordResetOption {
+    return func(s *PasswordResetService) {
+        s.ttl = ttl
+    }
+}
+
+// WithResetRateLimits define quantos pedidos cada email e cada IP podem
+// fazer por janela.
+func WithResetRateLimits(perEmail, perIP int, window time.Duration) PasswordResetOption {
+    return func(s *PasswordResetService) {
+        s.emailLimiter = ratelimit.NewLimiter(perEmail, window)
+        s.ipLimiter = ratelimit.NewLimiter(perIP, window)
+    }
+}
+
+// WithResetTxManager define a transação em que ResetPassword consome o
+// token, grava a senha e revoga as sessões. Sem ela, os repositórios
+// precisam ser os em memória.
+func WithResetTxManager(tx repository.TxManager) PasswordResetOption {
+    return func(s *PasswordResetService) {
+        s.tx = tx
+    }
+}
+
+// WithResetEmailPolicy deve ser a mesma política do UserService.
+func WithResetEmailPolicy(policy entity.EmailPolicy) PasswordResetOption {
+    return func(s *PasswordResetService) {
+        s.emails = policy
+    }
+}
+
+func WithResetLogger(logger *slog.Logger) PasswordResetOption {
+    return func(s *PasswordResetService) {
+        s.logger = logger
+    }
+}
+
+// NewPasswordResetService recebe em resetURL a página (do front-end) que
+// lê o token e chama POST /auth/reset-password.
+func NewPasswordResetService(
+    userRepo repository.UserRepository,
+    oneTimeTokenRepo repository.OneTimeTokenRepository,
+    refreshTokenRepo repository.RefreshTokenRepository,
+    hasher entity.PasswordHasher,
+    mailer mail.Mailer,
+    resetURL string,
+    opts ...PasswordResetOption,
+) *PasswordResetService {
+    s := &PasswordResetService{
+        userRepo:         userRepo,
+        oneTimeTokenRepo: oneTimeTokenRepo,
+        refreshTokenRepo: refreshTokenRepo,
+        hasher:           hasher,
+        emails:           entity.DefaultEmailPolicy,
+        mailer:           mailer,
+        resetURL:         resetURL,
+        ttl:              DefaultPasswordResetTTL,
+        emailLimiter:     ratelimit.NewLimiter(DefaultPasswordResetEmailRate, DefaultPasswordResetWindow),
+        ipLimiter:        ratelimit.NewLimiter(DefaultPasswordResetIPRate, DefaultPasswordResetWindow),
+        logger:           slog.Default(),
+    }
+    for _, opt := range opts {
+        opt(s)
+    }
+    if s.tx == nil {
+        s.tx = repository.NewInMemoryTxManager(repository.Repositories{
+            Users:         userRepo,
+            Outbox:        repository.NewInMemoryOutboxRepository(),
+            RefreshTokens: refreshTokenRepo,
+            OneTimeTokens: oneTimeTokenRepo,
+        })
+    }
+    return s
+}
+
+// RequestReset envia o link de reset se o email pertencer a um usuário.
+// O resultado é o mesmo para emails cadastrados ou não; só o limite por IP
+// gera erro. Estourar o limite por email apenas deixa de enviar, para que
+// ninguém use o endpoint para lotar a caixa de entrada de outra pessoa.
+func (s *PasswordResetService) RequestReset(ctx context.Context, req dto.ForgotPasswordRequest, clientIP string) (err error) {
+    ctx, span := tracer.Start(ctx, "PasswordResetService.RequestReset")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.ipLimiter.Allow(clientIP); err != nil {
+        s.logger.WarnContext(ctx, "password reset rate limited", "client_ip", clientIP)
+        return err
+    }
+    // Um endereço inválido não tem conta; responde igual a um desconhecido
+    email, err := s.emails.Parse(req.Email)
+    if err != nil {
+        return nil
+    }
+    if err := s.emailLimiter.Allow(email.Canonical()); err != nil {
+        s.logger.WarnContext(ctx, "password reset rate limited for email")
+        return nil
+    }
+
+    user, err := s.userRepo.FindByEmail(ctx, email.Canonical())
+    if err != nil {
+        return err
+    }
+    if user == nil {
+        return nil
+    }
+
+    // Gerar o token e enviar o email fica em segundo plano: se fosse feito
+    // aqui, um email cadastrado demoraria visivelmente mais para responder
+    // que um desconhecido. Falhas só podem ir para o log.
+    s.pending.Add(1)
+    go func() {
+        defer s.pending.Done()
+        ctx := context.WithoutCancel(ctx)
+        if err := s.sendReset(ctx, user); err != nil {
+            s.logger.ErrorContext(ctx, "password reset email failed", "user_id", user.ID, "error", err)
+        }
+    }()
+    return nil
+}
+
+// Wait espera os envios de RequestReset em andamento. O main chama depois
+// de parar o servidor, antes de fechar o pool.
+func (s *PasswordResetService) Wait() {
+    s.pending.Wait()
+}
+
+func (s *PasswordResetService) sendReset(ctx context.Context, user *entity.User) (err error) {
+    ctx, span := tracer.Start(ctx, "PasswordResetService.sendReset")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.oneTimeTokenRepo.InvalidateForUser(ctx, user.ID, entity.PurposePasswordReset, time.Now()); err != nil {
+        return err
+    }
+
+    token, err := generateToken()
+    if err != nil {
+        return err
+    }
+    record := entity.NewOneTimeToken(user.ID, entity.PurposePasswordReset, hashToken(token), user.NormalizedEmail, s.ttl)
+    if err := s.oneTimeTokenRepo.Save(ctx, record); err != nil {
+        return err
+    }
+
+    link := s.resetURL + "?" + url.Values{"token": {token}}.Encode()
+    msg := mail.Message{
+        To:      user.Email,
+        Subject: "Redefinição de senha",
+        Body: fmt.Sprintf("Olá %s,\n\nRecebemos um pedido para redefinir a sua senha. Para escolher uma nova senha, acesse:\n\n%s\n\n"+
+            "O link expira em %s e só pode ser usado uma vez. Se você não fez este pedido, ignore este email; sua senha continua a mesma.\n",
+            user.Name, link, formatTTL(s.ttl)),
+    }
+    if err := s.mailer.Send(ctx, msg); err != nil {
+        return fmt.Errorf("send password reset email: %w", err)
+    }
+
+    s.logger.InfoContext(ctx, "password reset email sent", "user_id", user.ID)
+    return nil
+}
+
+// ResetPassword consome o token, grava a nova senha e revoga todos os
+// refresh tokens do usuário, numa única transação: se algo falhar, a senha
+// antiga, as sessões e o token continuam como estavam.
+func (s *PasswordResetService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) (err error) {
+    ctx, span := tracer.Start(ctx, "PasswordResetService.ResetPassword")
+    defer func() { endSpan(span, err) }()
+
+    // Valida antes de consumir, para que uma senha fraca não queime o token
+    if err := entity.DefaultPasswordPolicy.Validate(req.Password); err != nil {
+        return err
+    }
+
+    now := time.Now()
+    var userID string
+    err = s.tx.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+        record, err := repos.OneTimeTokens.Consume(ctx, entity.PurposePasswordReset, hashToken(req.Token), now)
+        if err != nil {
+            return err
+        }
+        if record == nil {
+            return entity.ErrInvalidToken
+        }
+
+        user, err := repos.Users.FindByID(ctx, record.UserID)
+        if err != nil {
+            return err
+        }
+        if user == nil || user.NormalizedEmail != record.Email {
+            return entity.ErrInvalidToken
+        }
+
+        if err := user.SetPassword(req.Password, s.hasher); err != nil {
+            return err
+        }
+        if err := repos.Users.Save(ctx, user); err != nil {
+            return err
+        }
+        if err := repos.RefreshTokens.RevokeAllForUser(ctx, user.ID, now); err != nil {
+            return err
+        }
+        userID = user.ID
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    s.logger.InfoContext(ctx, "password reset", "user_id", userID)
+    return nil
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/mail"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/ratelimit"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+type passwordResetFixture struct {
+    reset  *PasswordResetService
+    auth   *AuthService
+    mailer *recordingMailer
+    repos  repository.Repositories
+}
+
+func newPasswordResetFixture(t *testing.T, opts ...PasswordResetOption) *passwordResetFixture {
+    t.Helper()
+    
+    userRepo := repository.NewUserRepository(repository.InMemory, nil)
+    oneTimeTokenRepo := repository.NewOneTimeTokenRepository(repository.InMemory, nil)
+    refreshTokenRepo := repository.NewRefreshTokenRepository(repository.InMemory, nil)
+    userService := NewUserService(userRepo)
+    mailer := &recordingMailer{}
+    
+    _, err := userService.CreateUser(adminContext(), dto.CreateUserRequest{
+        Name:     "João Silva",
+        Email:    "joao@email.com",
+        Password: "S3nhaForte",
+    })
+    if err != nil {
+        t.Fatalf("Expected no error creating user, got %v", err)
+    }
+    
+    return &passwordResetFixture{
+        reset:  NewPasswordResetService(userRepo, oneTimeTokenRepo, refreshTokenRepo, userService.hasher, mailer, "http://localhost:3000/reset-password", opts...),
+        auth:   NewAuthService(userRepo, refreshTokenRepo, userService.hasher, fakeIssuer{}),
+        mailer: mailer,
+        repos:  repository.Repositories{
+            Users:         userRepo,
+            Outbox:        repository.NewInMemoryOutboxRepository(),
+            RefreshTokens: refreshTokenRepo,
+            OneTimeTokens: oneTimeTokenRepo,
+        },
+    }
+}
+
+func TestPasswordReset_ChangesPasswordAndRevokesSessions(t *testing.T) {
+    // Arrange
+    f := newPasswordResetFixture(t)
+    ctx := context.Background()
+    
+    session, _ := f.auth.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "S3nhaForte"})
+    if err := f.reset.RequestReset(ctx, dto.ForgotPasswordRequest{Email: "joao@email.com"}, "10.0.0.1"); err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    f.reset.Wait()
+    token := f.mailer.lastToken(t)
+    
+    // Act
+    err := f.reset.ResetPassword(ctx, dto.ResetPasswordRequest{Token: token, Password: "N0vaSenhaForte"})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if _, err := f.auth.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "N0vaSenhaForte"}); err != nil {
+        t.Errorf("Expected login with new password, got %v", err)
+    }
+    if _, err := f.auth.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "S3nhaForte"}); !errors.Is(err, auth.ErrInvalidCredentials) {
+        t.Errorf("Expected old password to be rejected, got %v", err)
+    }
+    if _, err := f.auth.Refresh(ctx, dto.RefreshTokenRequest{RefreshToken: session.RefreshToken}); !errors.Is(err, auth.ErrInvalidRefreshToken) {
+        t.Errorf("Expected existing sessions to be revoked, got %v", err)
+    }
+    if err := f.reset.ResetPassword(ctx, dto.ResetPasswordRequest{Token: token, Password: "OutraSenha123"}); !errors.Is(err, entity.ErrInvalidToken) {
+        t.Errorf("Expected token to be single use, got %v", err)
+    }
+}
+
+func TestPasswordReset_CaseOnlyEmailEditKeepsToken(t *testing.T) {
+    // Arrange
+    f := newPasswordResetFixture(t)
+    ctx := context.Background()
+    
+    _ = f.reset.RequestReset(ctx, dto.ForgotPasswordRequest{Email: "joao@email.com"}, "10.0.0.1")
+    f.reset.Wait()
+    token := f.mailer.lastToken(t)
+    
+    user, _ := f.repos.Users.FindByEmail(ctx, "joao@email.com")
+    email, _ := entity.DefaultEmailPolicy.Parse("JOAO@email.com")
+    _ = user.UpdateEmail(email)
+    if err := f.repos.Users.Save(ctx, user); err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    // Act
+    err := f.reset.ResetPassword(ctx, dto.ResetPasswordRequest{Token: token, Password: "N0vaSenhaForte"})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected the token to survive a case-only edit, got %v", err)
+    }
+    if _, err := f.auth.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "N0vaSenhaForte"}); err != nil {
+        t.Errorf("Expected login with new password, got %v", err)
+    }
+}
+
+// failingRevoke faz RevokeAllForUser falhar enquanto fail for true.
+type failingRevoke struct {
+    repository.RefreshTokenRepository
+    fail *bool
+}
+
+func (r failingRevoke) RevokeAllForUser(ctx context.Context, userID string, at time.Time) error {
+    if *r.fail {
+        return errors.New("revoke failed")
+    }
+    return r.RefreshTokenRepository.RevokeAllForUser(ctx, userID, at)
+}
+
+func TestPasswordReset_FailedRevokeRollsBackEverything(t *testing.T) {
+    // Arrange
+    f := newPasswordResetFixture(t)
+    ctx := context.Background()
+    fail := true
+    f.reset.tx = repository.NewInMemoryTxManager(f.repos, repository.WithTxDecorator(func(repos repository.Repositories) repository.Repositories {
+        repos.RefreshTokens = failingRevoke{RefreshTokenRepository: repos.RefreshTokens, fail: &fail}
+        return repos
+    }))
+    
+    session, _ := f.auth.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "S3nhaForte"})
+    _ = f.reset.RequestReset(ctx, dto.ForgotPasswordRequest{Email: "joao@email.com"}, "10.0.0.1")
+    f.reset.Wait()
+    token := f.mailer.lastToken(t)
+    
+    // Act
+    err := f.reset.ResetPassword(ctx, dto.ResetPasswordRequest{Token: token, Password: "N0vaSenhaForte"})
+    
+    // Assert
+    if err == nil {
+        t.Fatal("Expected the revoke failure to be returned")
+    }
+    if _, err := f.auth.Login(ctx, dto.LoginRequest{Email: "joao@email.com", Password: "S3nhaForte"}); err != nil {
+        t.Errorf("Expected the old password to still work, got %v", err)
+    }
+    // O token não foi queimado: com o revoke de volta, a mesma chamada passa
+    fail = false
+    if err := f.reset.ResetPassword(ctx, dto.ResetPasswordRequest{Token: token, Password: "N0vaSenhaForte"}); err != nil {
+        t.Fatalf("Expected the token to remain usable, got %v", err)
+    }
+    if _, err := f.auth.Refresh(ctx, dto.RefreshTokenRequest{RefreshToken: session.RefreshToken}); !errors.Is(err, auth.ErrInvalidRefreshToken) {
+        t.Errorf("Expected sessions to be revoked after the retry, got %v", err)
+    }
+}
+
+func TestPasswordReset_WeakPasswordKeepsToken(t *testing.T) {
+    f := newPasswordResetFixture(t)
+    ctx := context.Background()
+    
+    _ = f.reset.RequestReset(ctx, dto.ForgotPasswordRequest{Email: "joao@email.com"}, "10.0.0.1")
+    f.reset.Wait()
+    token := f.mailer.lastToken(t)
+    
+    var validationErr *entity.ValidationError
+    if err := f.reset.ResetPassword(ctx, dto.ResetPasswordRequest{Token: token, Password: "fraca"}); !errors.As(err, &validationErr) {
+        t.Fatalf("Expected ValidationError, got %v", err)
+    }
+    if err := f.reset.ResetPassword(ctx, dto.ResetPasswordRequest{Token: token, Password: "N0vaSenhaForte"}); err != nil {
+        t.Errorf("Expected token to remain usable, got %v", err)
+    }
+}
+
+func TestPasswordReset_UnknownEmailLooksTheSame(t *testing.T) {
+    f := newPasswordResetFixture(t)
+    
+    err := f.reset.RequestReset(context.Background(), dto.ForgotPasswordRequest{Email: "ninguem@email.com"}, "10.0.0.1")
+    f.reset.Wait()
+    
+    if err != nil {
+        t.Errorf("Expected no error for unknown email, got %v", err)
+    }
+    if len(f.mailer.messages) != 0 {
+        t.Errorf("Expected no email to be sent, got %d", len(f.mailer.messages))
+    }
+}
+
+// blockingMailer segura cada envio até release ser fechado.
+type blockingMailer struct {
+    recordingMailer
+    release chan struct{}
+}
+
+func (m *blockingMailer) Send(ctx context.Context, msg mail.Message) error {
+    <-m.release
+    return m.recordingMailer.Send(ctx, msg)
+}
+
+func TestPasswordReset_RequestDoesNotWaitForMailer(t *testing.T) {
+    // Arrange
+    f := newPasswordResetFixture(t)
+    mailer := &blockingMailer{release: make(chan struct{})}
+    f.reset.mailer = mailer
+    
+    // Act
+    done := make(chan error, 1)
+    go func() {
+        done <- f.reset.RequestReset(context.Background(), dto.ForgotPasswordRequest{Email: "joao@email.com"}, "10.0.0.1")
+    }()
+    
+    // Assert: responde como para um email desconhecido, sem esperar o envio
+    select {
+    case err := <-done:
+        if err != nil {
+            t.Fatalf("Expected no error, got %v", err)
+        }
+    case <-time.After(time.Second):
+        t.Fatal("Expected RequestReset to return before the email is sent")
+    }
+    close(mailer.release)
+    f.reset.Wait()
+    if len(mailer.messages) != 1 {
+        t.Errorf("Expected the email to be sent in the background, got %d", len(mailer.messages))
+    }
+}
+
+func TestPasswordReset_RateLimits(t *testing.T) {
+    // Arrange
+    f := newPasswordResetFixture(t, WithResetRateLimits(1, 2, time.Hour))
+    ctx := context.Background()
+    req := dto.ForgotPasswordRequest{Email: "joao@email.com"}
+    
+    // Act & Assert
+    if err := f.reset.RequestReset(ctx, req, "10.0.0.1"); err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    // Limite por email: responde igual, mas não envia outro email
+    if err := f.reset.RequestReset(ctx, req, "10.0.0.1"); err != nil {
+        t.Errorf("Expected email limit to be silent, got %v", err)
+    }
+    f.reset.Wait()
+    if len(f.mailer.messages) != 1 {
+        t.Errorf("Expected a single email, got %d", len(f.mailer.messages))
+    }
+    // Limite por IP: erro explícito
+    if err := f.reset.RequestReset(ctx, req, "10.0.0.1"); !errors.Is(err, ratelimit.ErrLimitExceeded) {
+        t.Errorf("Expected ErrLimitExceeded for IP, got %v", err)
+    }
+    if err := f.reset.RequestReset(ctx, dto.ForgotPasswordRequest{Email: "maria@email.com"}, "10.0.0.2"); err != nil {
+        t.Errorf("Expected other IPs to be unaffected, got %v", err)
+    }
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+// PurgeJob remove definitivamente, em intervalos regulares, os usuários
+// excluídos logicamente há mais tempo que a retenção configurada.
+type PurgeJob struct {
+    userRepo  repository.UserRepository
+    retention time.Duration
+    interval  time.Duration
+    logger    *slog.Logger
+}
+
+func NewPurgeJob(userRepo repository.UserRepository, retention, interval time.Duration, logger *slog.Logger) *PurgeJob {
+    if interval <= 0 {
+        interval = time.Hour
+    }
+    return &PurgeJob{
+        userRepo:  userRepo,
+        retention: retention,
+        interval:  interval,
+        logger:    logger,
+    }
+}
+
+// Run executa um expurgo imediatamente e depois a cada intervalo, até o
+// contexto ser cancelado. Uma falha só é registrada; a próxima rodada tenta
+// de novo.
+func (j *PurgeJob) Run(ctx context.Context) {
+    ticker := time.NewTicker(j.interval)
+    defer ticker.Stop()
+    
+    for {
+        if _, err := j.PurgeOnce(ctx); err != nil && ctx.Err() == nil {
+            j.logger.ErrorContext(ctx, "failed to purge deleted users", "error", err)
+        }
+        
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+func (j *PurgeJob) PurgeOnce(ctx context.Context) (_ int64, err error) {
+    ctx, span := tracer.Start(ctx, "PurgeJob.PurgeOnce")
+    defer func() { endSpan(span, err) }()
+    
+    purged, err := j.userRepo.PurgeDeleted(ctx, time.Now().Add(-j.retention))
+    if err != nil {
+        return 0, err
+    }
+    if purged > 0 {
+        j.logger.InfoContext(ctx, "purged deleted users", "count", purged, "retention", j.retention.String())
+    }
+    return purged, nil
+}
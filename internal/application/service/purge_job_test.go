@@ -0,0 +1,47 @@
+package service
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+func TestPurgeJob_RemovesUsersPastRetention(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    old, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    recent, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "Maria Santos", Email: "maria@email.com"})
+    _ = service.DeleteUser(ctx, old.ID, dto.Precondition{})
+    _ = service.DeleteUser(ctx, recent.ID, dto.Precondition{})
+    
+    // Simula uma exclusão antiga
+    oldUser, _ := repo.FindDeletedByID(ctx, old.ID)
+    deletedAt := time.Now().Add(-48 * time.Hour)
+    oldUser.DeletedAt = &deletedAt
+    _ = repo.Save(ctx, oldUser)
+    
+    job := NewPurgeJob(repo, 24*time.Hour, time.Hour, slog.Default())
+    
+    // Act
+    purged, err := job.PurgeOnce(ctx)
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if purged != 1 {
+        t.Errorf("Expected 1 purged user, got %d", purged)
+    }
+    if u, _ := repo.FindDeletedByID(ctx, old.ID); u != nil {
+        t.Error("Expected old user to be purged")
+    }
+    if u, _ := repo.FindDeletedByID(ctx, recent.ID); u == nil {
+        t.Error("Expected recently deleted user to be kept")
+    }
+}
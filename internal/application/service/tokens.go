@@ -0,0 +1,25 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// generateToken gera 256 bits aleatórios em base64url.
+func generateToken() (string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", fmt.Errorf("generate token: %w", err)
+    }
+    return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken é o que vai para o banco: um vazamento da tabela não expõe
+// tokens utilizáveis.
+func hashToken(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
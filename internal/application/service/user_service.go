@@ -1,137 +1,665 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
+	"time"
 
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
 	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
 	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/broadcast"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/jsonpatch"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/password"
 	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+	"github.com/google/uuid"
+)
+
+const (
+    DefaultPageSize = 20
+    MaxPageSize     = 100
 )
 
 type UserService struct {
     userRepo repository.UserRepository
+    tx       repository.TxManager
+    policy   auth.Policy
+    hasher   entity.PasswordHasher
+    emails   entity.EmailPolicy
+    verifier *EmailVerificationService
+    changes  broadcast.Broadcaster
+    logger   *slog.Logger
+}
+
+type Option func(*UserService)
+
+func WithLogger(logger *slog.Logger) Option {
+    return func(s *UserService) {
+        s.logger = logger
+    }
+}
+
+// WithPolicy troca as regras de autorização (o padrão é auth.RolePolicy).
+func WithPolicy(policy auth.Policy) Option {
+    return func(s *UserService) {
+        s.policy = policy
+    }
+}
+
+// WithPasswordHasher troca o algoritmo das senhas (o padrão é argon2id).
+func WithPasswordHasher(hasher entity.PasswordHasher) Option {
+    return func(s *UserService) {
+        s.hasher = hasher
+    }
+}
+
+// WithEmailPolicy define a normalização e os domínios bloqueados dos
+// emails (o padrão é entity.DefaultEmailPolicy).
+func WithEmailPolicy(policy entity.EmailPolicy) Option {
+    return func(s *UserService) {
+        s.emails = policy
+    }
+}
+
+// WithEmailVerification envia o link de verificação ao criar o usuário e a
+// cada troca de email. Sem ela, RequestEmailVerification falha.
+func WithEmailVerification(verifier *EmailVerificationService) Option {
+    return func(s *UserService) {
+        s.verifier = verifier
+    }
+}
+
+// WithTxManager define onde rodam as operações de mais de um passo (ler,
+// conferir e gravar, junto com os eventos no outbox). Sem ela, userRepo
+// precisa ser o repositório em memória e os eventos vão para um outbox em
+// memória que ninguém lê.
+func WithTxManager(tx repository.TxManager) Option {
+    return func(s *UserService) {
+        s.tx = tx
+    }
+}
+
+// WithBroadcaster define para onde vão as notificações de alteração lidas
+// por SubscribeChanges (o padrão é um broadcast.Hub só deste processo).
+func WithBroadcaster(changes broadcast.Broadcaster) Option {
+    return func(s *UserService) {
+        s.changes = changes
+    }
 }
 
-func NewUserService(userRepo repository.UserRepository) *UserService {
-    return &UserService{
+func NewUserService(userRepo repository.UserRepository, opts ...Option) *UserService {
+    s := &UserService{
         userRepo: userRepo,
+        policy:   auth.RolePolicy{},
+        hasher:   password.DefaultHasher(),
+        emails:   entity.DefaultEmailPolicy,
+        logger:   slog.Default(),
     }
+    for _, opt := range opts {
+        opt(s)
+    }
+    if s.tx == nil {
+        s.tx = repository.NewInMemoryTxManager(repository.Repositories{
+            Users:  userRepo,
+            Outbox: repository.NewInMemoryOutboxRepository(),
+        })
+    }
+    if s.changes == nil {
+        s.changes = broadcast.NewHub(broadcast.DefaultBufferSize, broadcast.DefaultClientBuffer)
+    }
+    return s
 }
 
-func (s *UserService) CreateUser(req dto.CreateUserRequest) (*dto.UserResponse, error) {
-    existingUser, err := s.userRepo.FindByEmail(req.Email)
+func (s *UserService) CreateUser(ctx context.Context, req dto.CreateUserRequest) (_ *dto.UserResponse, err error) {
+    ctx, span := tracer.Start(ctx, "UserService.CreateUser")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.authorize(ctx, auth.ActionCreateUser, ""); err != nil {
+        return nil, err
+    }
+
+    email, err := s.emails.Accept(req.Email)
     if err != nil {
         return nil, err
     }
-    if existingUser != nil {
-        return nil, errors.New("email already exists")
+
+    // Não há busca prévia pelo email: ela leria fora da transação e não
+    // impediria um cadastro simultâneo. O Save devolve EmailTakenError.
+    var opts []entity.UserOption
+    if req.Password != "" {
+        opts = append(opts, entity.WithPassword(req.Password, s.hasher))
     }
 
-    newUser, err := entity.NewUser(req.Name, req.Email)
+    newUser, err := entity.NewUser(req.Name, email, opts...)
     if err != nil {
         return nil, err
     }
 
-		if err := s.userRepo.Save(newUser); err != nil {
+    var created *entity.User
+    err = s.tx.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+        // Cada tentativa parte do usuário ainda não gravado, com os eventos
+        user := *newUser
+        if err := repos.Users.Save(ctx, &user); err != nil {
+            return err
+        }
+        if err := recordEvents(ctx, repos.Outbox, &user); err != nil {
+            return err
+        }
+        created = &user
+        return nil
+    })
+    if err != nil {
         return nil, err
     }
 
-    return s.toUserResponse(newUser), nil
+    s.logger.InfoContext(ctx, "user created", "user_id", created.ID)
+    s.sendVerification(ctx, created)
+    response := toUserResponse(created)
+    s.notify(ctx, broadcast.EventUserCreated, response)
+    return response, nil
 }
 
-func (s *UserService) GetUserByID(id string) (*dto.UserResponse, error) {
+func (s *UserService) GetUserByID(ctx context.Context, id string) (_ *dto.UserResponse, err error) {
+    ctx, span := tracer.Start(ctx, "UserService.GetUserByID")
+    defer func() { endSpan(span, err) }()
+
     if id == "" {
-        return nil, errors.New("id is required")
+        return nil, entity.NewValidationError("id", entity.CodeRequired, "id is required")
     }
 
-    user, err := s.userRepo.FindByID(id)
+    if err := s.authorize(ctx, auth.ActionReadUser, id); err != nil {
+        return nil, err
+    }
+
+    user, err := s.userRepo.FindByID(ctx, id)
     if err != nil {
         return nil, err
     }
     if user == nil {
-        return nil, errors.New("user not found")
+        return nil, entity.ErrUserNotFound
     }
 
-    return s.toUserResponse(user), nil
+    return toUserResponse(user), nil
 }
 
-func (s *UserService) GetAllUsers() ([]*dto.UserResponse, error) {
-    users, err := s.userRepo.FindAll()
+func (s *UserService) GetAllUsers(ctx context.Context, query dto.ListUsersQuery) (_ *dto.UserListResponse, err error) {
+    ctx, span := tracer.Start(ctx, "UserService.GetAllUsers")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.authorize(ctx, auth.ActionListUsers, ""); err != nil {
+        return nil, err
+    }
+
+    opts := repository.ListOptions{
+        Limit:         query.Limit,
+        Sort:          repository.SortDesc,
+        NamePrefix:    query.NamePrefix,
+        EmailPrefix:   query.EmailPrefix,
+        CreatedAfter:  query.CreatedAfter,
+        CreatedBefore: query.CreatedBefore,
+    }
+    if opts.Limit <= 0 {
+        opts.Limit = DefaultPageSize
+    }
+    if opts.Limit > MaxPageSize {
+        opts.Limit = MaxPageSize
+    }
+    if query.Sort == "created_at" {
+        opts.Sort = repository.SortAsc
+    }
+    if query.Cursor != "" {
+        cursor, err := repository.DecodeCursor(query.Cursor)
+        if err != nil {
+            return nil, entity.NewValidationError("cursor", entity.CodeInvalid, err.Error())
+        }
+        if cursor.Sort != opts.Sort {
+            return nil, entity.NewValidationError("cursor", entity.CodeInvalid, repository.ErrCursorSortMismatch.Error())
+        }
+        opts.After = cursor
+    }
+
+    // Busca um item a mais para saber se existe próxima página
+    limit := opts.Limit
+    opts.Limit++
+    users, err := s.userRepo.FindAll(ctx, opts)
     if err != nil {
         return nil, err
     }
 
-    var responses []*dto.UserResponse
+    response := &dto.UserListResponse{Data: []*dto.UserResponse{}}
+    if len(users) > limit {
+        users = users[:limit]
+        last := users[len(users)-1]
+        response.NextCursor = repository.Cursor{CreatedAt: last.CreatedAt, ID: last.ID, Sort: opts.Sort}.Encode()
+    }
     for _, u := range users {
-        responses = append(responses, s.toUserResponse(u))
+        response.Data = append(response.Data, toUserResponse(u))
+    }
+
+    return response, nil
+}
+
+// UpdateUser substitui nome e email (PUT) se a versão atual satisfizer
+// precondition.
+func (s *UserService) UpdateUser(ctx context.Context, id string, req dto.UpdateUserRequest, precondition dto.Precondition) (_ *dto.UserResponse, err error) {
+    ctx, span := tracer.Start(ctx, "UserService.UpdateUser")
+    defer func() { endSpan(span, err) }()
+
+    var user *entity.User
+    var emailChanged bool
+    err = s.tx.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+        var err error
+        if user, err = s.findForUpdate(ctx, repos.Users, id, precondition); err != nil {
+            return err
+        }
+        emailChanged, err = s.applyChanges(ctx, repos, user, req.Name, req.Email, precondition)
+        return err
+    })
+    if err != nil {
+        return nil, err
     }
 
-    return responses, nil
+    return s.updated(ctx, user, emailChanged), nil
 }
 
-func (s *UserService) UpdateUser(id string, req dto.UpdateUserRequest) (*dto.UserResponse, error) {
+// PatchUser aplica um JSON Merge Patch ou JSON Patch sobre a representação
+// do usuário (a mesma do GET). Só name e email podem mudar; as alterações
+// passam pelas mesmas regras da entidade que o PUT.
+func (s *UserService) PatchUser(ctx context.Context, id string, req dto.PatchUserRequest, precondition dto.Precondition) (_ *dto.UserResponse, err error) {
+    ctx, span := tracer.Start(ctx, "UserService.PatchUser")
+    defer func() { endSpan(span, err) }()
+
+    var user *entity.User
+    var emailChanged bool
+    err = s.tx.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+        var err error
+        if user, err = s.findForUpdate(ctx, repos.Users, id, precondition); err != nil {
+            return err
+        }
+
+        current := toUserResponse(user)
+        doc, err := json.Marshal(current)
+        if err != nil {
+            return err
+        }
+        patched, err := jsonpatch.Apply(req.ContentType, doc, req.Document)
+        if err != nil {
+            return err
+        }
+
+        var target dto.UserResponse
+        dec := json.NewDecoder(bytes.NewReader(patched))
+        dec.DisallowUnknownFields()
+        if err := dec.Decode(&target); err != nil {
+            return entity.NewValidationError("body", entity.CodeInvalid, fmt.Sprintf("patched user is invalid: %v", err))
+        }
+        if field := readOnlyFieldChanged(current, &target); field != "" {
+            return entity.NewValidationError(field, entity.CodeReadOnly, field+" is read-only")
+        }
+
+        emailChanged, err = s.applyChanges(ctx, repos, user, target.Name, target.Email, precondition)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return s.updated(ctx, user, emailChanged), nil
+}
+
+// findForUpdate carrega o usuário que será alterado e confere autorização
+// e pré-condição.
+func (s *UserService) findForUpdate(ctx context.Context, users repository.UserRepository, id string, precondition dto.Precondition) (*entity.User, error) {
     if id == "" {
-        return nil, errors.New("id is required")
+        return nil, entity.NewValidationError("id", entity.CodeRequired, "id is required")
+    }
+
+    if err := s.authorize(ctx, auth.ActionUpdateUser, id); err != nil {
+        return nil, err
     }
 
-    user, err := s.userRepo.FindByID(id)
+    user, err := users.FindByID(ctx, id)
     if err != nil {
         return nil, err
     }
     if user == nil {
-        return nil, errors.New("user not found")
+        return nil, entity.ErrUserNotFound
+    }
+    if !precondition.Matches(user.Version) {
+        return nil, entity.ErrPreconditionFailed
     }
+    return user, nil
+}
 
-    if req.Email != "" && req.Email != user.Email {
-        existingUser, err := s.userRepo.FindByEmail(req.Email)
+// applyChanges leva o usuário ao nome e email informados e salva, junto
+// com os eventos gerados. Campos vazios são rejeitados pela entidade, não
+// ignorados. Devolve se o email mudou de caixa de correio.
+func (s *UserService) applyChanges(ctx context.Context, repos repository.Repositories, user *entity.User, name, rawEmail string, precondition dto.Precondition) (bool, error) {
+    var emailChanged bool
+    if rawEmail != user.Email {
+        email, err := s.emails.Accept(rawEmail)
         if err != nil {
-            return nil, err
+            return false, err
         }
-        if existingUser != nil {
-            return nil, errors.New("email already exists")
+        
+        // Só muda de caixa de correio se a forma canônica mudar
+        emailChanged = email.Canonical() != user.NormalizedEmail
+        if emailChanged {
+            existingUser, err := repos.Users.FindByEmail(ctx, email.Canonical())
+            if err != nil {
+                return false, err
+            }
+            if existingUser != nil {
+                return false, entity.ErrEmailTaken
+            }
         }
         
-        if err := user.UpdateEmail(req.Email); err != nil {
-            return nil, err
+        if err := user.UpdateEmail(email); err != nil {
+            return false, err
         }
     }
 
-		if req.Name != "" && req.Name != user.Name {
-        if err := user.UpdateName(req.Name); err != nil {
-            return nil, err
+    if name != user.Name {
+        if err := user.UpdateName(name); err != nil {
+            return false, err
         }
     }
 
-		if err := s.userRepo.Save(user); err != nil {
-        return nil, err
+    if err := s.save(ctx, repos.Users, user, precondition); err != nil {
+        return false, err
+    }
+    if err := recordEvents(ctx, repos.Outbox, user); err != nil {
+        return false, err
     }
+    return emailChanged, nil
+}
 
-    return s.toUserResponse(user), nil
+// updated faz o que vem depois de uma alteração confirmada: log e, se o
+// email mudou, o envio de um novo link de verificação, e a notificação.
+func (s *UserService) updated(ctx context.Context, user *entity.User, emailChanged bool) *dto.UserResponse {
+    s.logger.InfoContext(ctx, "user updated", "user_id", user.ID)
+    if emailChanged {
+        s.sendVerification(ctx, user)
+    }
+    response := toUserResponse(user)
+    s.notify(ctx, broadcast.EventUserUpdated, response)
+    return response
 }
 
-func (s *UserService) DeleteUser(id string) error {
+func (s *UserService) DeleteUser(ctx context.Context, id string, precondition dto.Precondition) (err error) {
+    ctx, span := tracer.Start(ctx, "UserService.DeleteUser")
+    defer func() { endSpan(span, err) }()
+
     if id == "" {
-        return errors.New("id is required")
+        return entity.NewValidationError("id", entity.CodeRequired, "id is required")
+    }
+
+    if err := s.authorize(ctx, auth.ActionDeleteUser, id); err != nil {
+        return err
     }
 
-    user, err := s.userRepo.FindByID(id)
+    err = s.tx.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+        user, err := repos.Users.FindByID(ctx, id)
+        if err != nil {
+            return err
+        }
+        if user == nil {
+            return entity.ErrUserNotFound
+        }
+        if !precondition.Matches(user.Version) {
+            return entity.ErrPreconditionFailed
+        }
+
+        if err := user.SoftDelete(time.Now()); err != nil {
+            return err
+        }
+        if err := s.save(ctx, repos.Users, user, precondition); err != nil {
+            return err
+        }
+        return recordEvents(ctx, repos.Outbox, user)
+    })
+    if err != nil {
+        return err
+    }
+
+    s.logger.InfoContext(ctx, "user deleted", "user_id", id)
+    s.notify(ctx, broadcast.EventUserDeleted, dto.UserDeletedNotification{ID: id})
+    return nil
+}
+
+// PurgeUser remove o usuário definitivamente, inclusive se já estiver
+// excluído logicamente. Não há como desfazer. Um usuário ainda ativo gera
+// o mesmo UserDeleted da exclusão lógica, já que os consumidores do outbox
+// não veriam outro sinal da saída dele.
+func (s *UserService) PurgeUser(ctx context.Context, id string, precondition dto.Precondition) (err error) {
+    ctx, span := tracer.Start(ctx, "UserService.PurgeUser")
+    defer func() { endSpan(span, err) }()
+
+    if id == "" {
+        return entity.NewValidationError("id", entity.CodeRequired, "id is required")
+    }
+
+    if err := s.authorize(ctx, auth.ActionPurgeUser, id); err != nil {
+        return err
+    }
+
+    err = s.tx.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+        user, err := findActiveOrDeleted(ctx, repos.Users, id)
+        if err != nil {
+            return err
+        }
+        if user == nil {
+            return entity.ErrUserNotFound
+        }
+        if !precondition.Matches(user.Version) {
+            return entity.ErrPreconditionFailed
+        }
+
+        if !user.IsDeleted() {
+            if err := user.SoftDelete(time.Now()); err != nil {
+                return err
+            }
+            if err := recordEvents(ctx, repos.Outbox, user); err != nil {
+                return err
+            }
+        }
+        return repos.Users.Delete(ctx, id)
+    })
+    if err != nil {
+        return err
+    }
+
+    s.logger.InfoContext(ctx, "user purged", "user_id", id)
+    s.notify(ctx, broadcast.EventUserDeleted, dto.UserDeletedNotification{ID: id})
+    return nil
+}
+
+// RestoreUser desfaz a exclusão lógica. Falha com ErrEmailTaken se outro
+// usuário ativo tiver ficado com o mesmo email nesse meio tempo.
+func (s *UserService) RestoreUser(ctx context.Context, id string) (_ *dto.UserResponse, err error) {
+    ctx, span := tracer.Start(ctx, "UserService.RestoreUser")
+    defer func() { endSpan(span, err) }()
+
+    if id == "" {
+        return nil, entity.NewValidationError("id", entity.CodeRequired, "id is required")
+    }
+
+    if err := s.authorize(ctx, auth.ActionRestoreUser, id); err != nil {
+        return nil, err
+    }
+
+    var user *entity.User
+    err = s.tx.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+        var err error
+        user, err = repos.Users.FindDeletedByID(ctx, id)
+        if err != nil {
+            return err
+        }
+        if user == nil {
+            return entity.ErrUserNotFound
+        }
+
+        existingUser, err := repos.Users.FindByEmail(ctx, user.NormalizedEmail)
+        if err != nil {
+            return err
+        }
+        if existingUser != nil {
+            return entity.ErrEmailTaken
+        }
+
+        if err := user.Restore(time.Now()); err != nil {
+            return err
+        }
+        return repos.Users.Save(ctx, user)
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    s.logger.InfoContext(ctx, "user restored", "user_id", id)
+    // Para quem acompanha a lista, o usuário restaurado volta como alteração
+    response := toUserResponse(user)
+    s.notify(ctx, broadcast.EventUserUpdated, response)
+    return response, nil
+}
+
+// RequestEmailVerification reenvia o link de verificação para o email atual.
+func (s *UserService) RequestEmailVerification(ctx context.Context, id string) (err error) {
+    ctx, span := tracer.Start(ctx, "UserService.RequestEmailVerification")
+    defer func() { endSpan(span, err) }()
+
+    if s.verifier == nil {
+        return errors.New("email verification is not configured")
+    }
+
+    if err := s.authorize(ctx, auth.ActionUpdateUser, id); err != nil {
+        return err
+    }
+
+    user, err := s.userRepo.FindByID(ctx, id)
     if err != nil {
         return err
     }
     if user == nil {
-        return errors.New("user not found")
+        return entity.ErrUserNotFound
+    }
+
+    return s.verifier.Send(ctx, user)
+}
+
+// SubscribeChanges assina as notificações de alteração de usuários, que
+// revelam todos eles: exige a mesma permissão da listagem.
+func (s *UserService) SubscribeChanges(ctx context.Context, lastEventID string) (*broadcast.Subscription, error) {
+    if err := s.authorize(ctx, auth.ActionListUsers, ""); err != nil {
+        return nil, err
     }
+    return s.changes.Subscribe(lastEventID), nil
+}
 
-    return s.userRepo.Delete(id)
+// notify avisa os clientes de uma alteração já confirmada. Uma falha só vai
+// para o log: a alteração está feita e quem perder a notificação recupera
+// o estado na listagem.
+func (s *UserService) notify(ctx context.Context, eventType string, data any) {
+    payload, err := json.Marshal(data)
+    if err == nil {
+        err = s.changes.Publish(ctx, broadcast.Event{ID: uuid.New().String(), Type: eventType, Data: payload})
+    }
+    if err != nil {
+        s.logger.WarnContext(ctx, "failed to broadcast user change", "event_type", eventType, "error", err)
+    }
+}
+
+// save grava o usuário. Se o cliente mandou If-Match, uma escrita
+// concorrente entre a leitura e a gravação também é falha de pré-condição.
+func (s *UserService) save(ctx context.Context, users repository.UserRepository, user *entity.User, precondition dto.Precondition) error {
+    err := users.Save(ctx, user)
+    if precondition.Present && errors.Is(err, entity.ErrVersionConflict) {
+        return entity.ErrPreconditionFailed
+    }
+    return err
+}
+
+// recordEvents grava no outbox os eventos pendentes do usuário. Deve rodar
+// na mesma transação do Save que os tornou verdade.
+func recordEvents(ctx context.Context, outbox repository.OutboxRepository, user *entity.User) error {
+    events := user.Events()
+    if len(events) == 0 {
+        return nil
+    }
+    messages := make([]*entity.OutboxMessage, 0, len(events))
+    for _, event := range events {
+        message, err := entity.NewOutboxMessage(event)
+        if err != nil {
+            return err
+        }
+        messages = append(messages, message)
+    }
+    if err := outbox.Add(ctx, messages...); err != nil {
+        return err
+    }
+    user.ClearEvents()
+    return nil
+}
+
+func findActiveOrDeleted(ctx context.Context, users repository.UserRepository, id string) (*entity.User, error) {
+    user, err := users.FindByID(ctx, id)
+    if err != nil || user != nil {
+        return user, err
+    }
+    return users.FindDeletedByID(ctx, id)
+}
+
+// sendVerification não falha a operação principal: o usuário já foi salvo e
+// pode pedir um novo link depois.
+func (s *UserService) sendVerification(ctx context.Context, user *entity.User) {
+    if s.verifier == nil {
+        return
+    }
+    if err := s.verifier.Send(ctx, user); err != nil {
+        s.logger.WarnContext(ctx, "failed to send verification email", "user_id", user.ID, "error", err)
+    }
+}
+
+// readOnlyFieldChanged devolve o primeiro campo somente leitura alterado
+// pelo patch, ou "" se nenhum foi.
+func readOnlyFieldChanged(before, after *dto.UserResponse) string {
+    switch {
+    case after.ID != before.ID:
+        return "id"
+    case after.EmailVerifiedAt != before.EmailVerifiedAt:
+        return "email_verified_at"
+    case after.CreatedAt != before.CreatedAt:
+        return "created_at"
+    case after.UpdatedAt != before.UpdatedAt:
+        return "updated_at"
+    case after.Version != before.Version:
+        return "version"
+    }
+    return ""
+}
+
+// authorize consulta a política com o principal autenticado no contexto
+func (s *UserService) authorize(ctx context.Context, action auth.Action, ownerID string) error {
+    principal, _ := auth.PrincipalFromContext(ctx)
+    return s.policy.Authorize(principal, action, ownerID)
 }
 
 // Converter entity para DTO de resposta
-func (s *UserService) toUserResponse(u *entity.User) *dto.UserResponse {
-    return &dto.UserResponse{
+func toUserResponse(u *entity.User) *dto.UserResponse {
+    response := &dto.UserResponse{
         ID:        u.ID,
         Name:      u.Name,
         Email:     u.Email,
         CreatedAt: u.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
         UpdatedAt: u.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+        Version:   u.Version,
+    }
+    if u.EmailVerifiedAt != nil {
+        response.EmailVerifiedAt = u.EmailVerifiedAt.Format("2006-01-02T15:04:05Z07:00")
     }
+    return response
 }
\ No newline at end of file
@@ -1,9 +1,16 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
 	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/jsonpatch"
 	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
 )
 
@@ -11,6 +18,7 @@ func TestUserService_CreateUser(t *testing.T) {
     // Arrange
     repo := repository.NewUserRepository(repository.InMemory, nil)
     service := NewUserService(repo)
+    ctx := adminContext()
     
     req := dto.CreateUserRequest{
         Name:  "João Silva",
@@ -18,7 +26,7 @@ func TestUserService_CreateUser(t *testing.T) {
     }
     
     // Act
-    user, err := service.CreateUser(req)
+    user, err := service.CreateUser(ctx, req)
     
     // Assert
     if err != nil {
@@ -42,6 +50,7 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
     // Arrange
     repo := repository.NewUserRepository(repository.InMemory, nil)
     service := NewUserService(repo)
+    ctx := adminContext()
     
     req := dto.CreateUserRequest{
         Name:  "João Silva",
@@ -49,21 +58,146 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
     }
     
     // Criar primeiro usuário
-    _, err := service.CreateUser(req)
+    _, err := service.CreateUser(ctx, req)
     if err != nil {
         t.Fatalf("Failed to create first user: %v", err)
     }
     
     // Act - tentar criar usuário com mesmo email
-    _, err = service.CreateUser(req)
+    _, err = service.CreateUser(ctx, req)
     
     // Assert
     if err == nil {
         t.Error("Expected error for duplicate email")
     }
     
-    if err.Error() != "email already exists" {
-        t.Errorf("Expected 'email already exists', got %s", err.Error())
+    if !errors.Is(err, entity.ErrEmailTaken) {
+        t.Errorf("Expected ErrEmailTaken, got %v", err)
+    }
+}
+
+func TestUserService_CreateUser_DuplicateEmailIgnoresCase(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    if _, err := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "Joao@Email.com"}); err != nil {
+        t.Fatalf("Failed to create first user: %v", err)
+    }
+    
+    // Act
+    _, err := service.CreateUser(ctx, dto.CreateUserRequest{Name: "Outro João", Email: "joao@EMAIL.COM"})
+    
+    // Assert
+    if !errors.Is(err, entity.ErrEmailTaken) {
+        t.Errorf("Expected ErrEmailTaken, got %v", err)
+    }
+}
+
+func TestUserService_CreateUser_ConcurrentSameEmail(t *testing.T) {
+    // Arrange
+    const attempts = 20
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    emails := []string{"joao@email.com", "JOAO@email.com", "Joao@EMAIL.com"}
+    errs := make(chan error, attempts)
+    start := make(chan struct{})
+    var wg sync.WaitGroup
+    
+    // Act: sem busca prévia pelo email, só o Save desempata
+    for i := 0; i < attempts; i++ {
+        wg.Add(1)
+        go func(email string) {
+            defer wg.Done()
+            <-start
+            _, err := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: email})
+            errs <- err
+        }(emails[i%len(emails)])
+    }
+    close(start)
+    wg.Wait()
+    close(errs)
+    
+    // Assert
+    created := 0
+    for err := range errs {
+        switch {
+        case err == nil:
+            created++
+        case !errors.Is(err, entity.ErrEmailTaken):
+            t.Errorf("Expected ErrEmailTaken, got %v", err)
+        }
+    }
+    if created != 1 {
+        t.Errorf("Expected exactly one user created, got %d", created)
+    }
+    if users, _ := repo.FindAll(ctx, repository.ListOptions{}); len(users) != 1 {
+        t.Errorf("Expected 1 stored user, got %d", len(users))
+    }
+}
+
+func TestUserService_CreateUser_EmailPolicy(t *testing.T) {
+    policy := entity.EmailPolicy{NormalizeProviders: true, BlockedDomains: []string{"mailinator.com"}}
+    
+    tests := []struct {
+        name      string
+        email     string
+        wantEmail string
+        wantCode  string
+    }{
+        {name: "domínio em minúsculas", email: "Joao@Email.COM", wantEmail: "Joao@email.com"},
+        {name: "domínio internacional", email: "joao@exâmple.com", wantEmail: "joao@xn--exmple-xta.com"},
+        {name: "nome de exibição", email: "João <joao@email.com>", wantCode: entity.CodeInvalid},
+        {name: "sem TLD", email: "joao@localhost", wantCode: entity.CodeInvalid},
+        {name: "domínio bloqueado", email: "joao@mailinator.com", wantCode: entity.CodeBlockedDomain},
+        {name: "subdomínio bloqueado", email: "joao@eu.mailinator.com", wantCode: entity.CodeBlockedDomain},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            // Arrange
+            service := NewUserService(repository.NewUserRepository(repository.InMemory, nil), WithEmailPolicy(policy))
+            
+            // Act
+            user, err := service.CreateUser(adminContext(), dto.CreateUserRequest{Name: "João Silva", Email: tt.email})
+            
+            // Assert
+            if tt.wantCode != "" {
+                var validationErr *entity.ValidationError
+                if !errors.As(err, &validationErr) || validationErr.Fields[0].Code != tt.wantCode {
+                    t.Fatalf("Expected validation error %s, got %v", tt.wantCode, err)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Expected no error, got %v", err)
+            }
+            if user.Email != tt.wantEmail {
+                t.Errorf("Expected email %s, got %s", tt.wantEmail, user.Email)
+            }
+        })
+    }
+}
+
+func TestUserService_CreateUser_NormalizesGmail(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo, WithEmailPolicy(entity.EmailPolicy{NormalizeProviders: true}))
+    ctx := adminContext()
+    
+    if _, err := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao.silva@gmail.com"}); err != nil {
+        t.Fatalf("Failed to create first user: %v", err)
+    }
+    
+    // Act
+    _, err := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "JoaoSilva+promo@googlemail.com"})
+    
+    // Assert
+    if !errors.Is(err, entity.ErrEmailTaken) {
+        t.Errorf("Expected ErrEmailTaken, got %v", err)
     }
 }
 
@@ -71,16 +205,17 @@ func TestUserService_GetUserByID(t *testing.T) {
     // Arrange
     repo := repository.NewUserRepository(repository.InMemory, nil)
     service := NewUserService(repo)
+    ctx := adminContext()
     
     // Criar usuário
     createReq := dto.CreateUserRequest{
         Name:  "João Silva",
         Email: "joao@email.com",
     }
-    createdUser, _ := service.CreateUser(createReq)
+    createdUser, _ := service.CreateUser(ctx, createReq)
     
     // Act
-    user, err := service.GetUserByID(createdUser.ID)
+    user, err := service.GetUserByID(ctx, createdUser.ID)
     
     // Assert
     if err != nil {
@@ -96,17 +231,18 @@ func TestUserService_GetUserByID_NotFound(t *testing.T) {
     // Arrange
     repo := repository.NewUserRepository(repository.InMemory, nil)
     service := NewUserService(repo)
+    ctx := adminContext()
     
     // Act
-    _, err := service.GetUserByID("non-existent-id")
+    _, err := service.GetUserByID(ctx, "non-existent-id")
     
     // Assert
     if err == nil {
         t.Error("Expected error for non-existent user")
     }
     
-    if err.Error() != "user not found" {
-        t.Errorf("Expected 'user not found', got %s", err.Error())
+    if !errors.Is(err, entity.ErrUserNotFound) {
+        t.Errorf("Expected ErrUserNotFound, got %v", err)
     }
 }
 
@@ -114,19 +250,21 @@ func TestUserService_UpdateUser(t *testing.T) {
     // Arrange
     repo := repository.NewUserRepository(repository.InMemory, nil)
     service := NewUserService(repo)
+    ctx := adminContext()
     
     // Criar usuário
     createReq := dto.CreateUserRequest{
         Name:  "João Silva",
         Email: "joao@email.com",
     }
-    createdUser, _ := service.CreateUser(createReq)
+    createdUser, _ := service.CreateUser(ctx, createReq)
     
     // Act
     updateReq := dto.UpdateUserRequest{
-        Name: "João Santos",
+        Name:  "João Santos",
+        Email: "joao@email.com",
     }
-    updatedUser, err := service.UpdateUser(createdUser.ID, updateReq)
+    updatedUser, err := service.UpdateUser(ctx, createdUser.ID, updateReq, dto.Precondition{})
     
     // Assert
     if err != nil {
@@ -146,16 +284,17 @@ func TestUserService_DeleteUser(t *testing.T) {
     // Arrange
     repo := repository.NewUserRepository(repository.InMemory, nil)
     service := NewUserService(repo)
+    ctx := adminContext()
     
     // Criar usuário
     createReq := dto.CreateUserRequest{
         Name:  "João Silva",
         Email: "joao@email.com",
     }
-    createdUser, _ := service.CreateUser(createReq)
+    createdUser, _ := service.CreateUser(ctx, createReq)
     
     // Act
-    err := service.DeleteUser(createdUser.ID)
+    err := service.DeleteUser(ctx, createdUser.ID, dto.Precondition{})
     
     // Assert
     if err != nil {
@@ -163,8 +302,377 @@ func TestUserService_DeleteUser(t *testing.T) {
     }
     
     // Verificar se foi deletado
-    _, err = service.GetUserByID(createdUser.ID)
+    _, err = service.GetUserByID(ctx, createdUser.ID)
     if err == nil {
         t.Error("Expected error when getting deleted user")
     }
-}
\ No newline at end of file
+}
+func TestUserService_GetAllUsers_Pagination(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    for _, name := range []string{"Ana", "Bruno", "Carla", "Daniel", "Eduarda"} {
+        _, err := service.CreateUser(ctx, dto.CreateUserRequest{
+            Name:  name,
+            Email: strings.ToLower(name) + "@email.com",
+        })
+        if err != nil {
+            t.Fatalf("Failed to create user %s: %v", name, err)
+        }
+    }
+    
+    // Act - percorrer todas as páginas
+    seen := make(map[string]bool)
+    query := dto.ListUsersQuery{Limit: 2, Sort: "created_at"}
+    pages := 0
+    for {
+        page, err := service.GetAllUsers(ctx, query)
+        if err != nil {
+            t.Fatalf("Expected no error, got %v", err)
+        }
+        pages++
+        for _, u := range page.Data {
+            if seen[u.ID] {
+                t.Errorf("User %s returned twice", u.ID)
+            }
+            seen[u.ID] = true
+        }
+        if page.NextCursor == "" {
+            break
+        }
+        query.Cursor = page.NextCursor
+    }
+    
+    // Assert
+    if len(seen) != 5 {
+        t.Errorf("Expected 5 users, got %d", len(seen))
+    }
+    if pages != 3 {
+        t.Errorf("Expected 3 pages, got %d", pages)
+    }
+    
+    // Um cursor de created_at não serve para -created_at
+    first, _ := service.GetAllUsers(ctx, dto.ListUsersQuery{Limit: 2, Sort: "created_at"})
+    _, err := service.GetAllUsers(ctx, dto.ListUsersQuery{Limit: 2, Sort: "-created_at", Cursor: first.NextCursor})
+    var validationErr *entity.ValidationError
+    if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "cursor" {
+        t.Errorf("Expected validation error on cursor with a different sort, got %v", err)
+    }
+}
+
+func TestUserService_GetAllUsers_Filters(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    service.CreateUser(ctx, dto.CreateUserRequest{Name: "Maria Santos", Email: "maria@email.com"})
+    
+    // Act
+    page, err := service.GetAllUsers(ctx, dto.ListUsersQuery{NamePrefix: "jo"})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if len(page.Data) != 1 || page.Data[0].Name != "João Silva" {
+        t.Errorf("Expected only 'João Silva', got %+v", page.Data)
+    }
+    
+    _, err = service.GetAllUsers(ctx, dto.ListUsersQuery{Cursor: "not-a-cursor"})
+    var validationErr *entity.ValidationError
+    if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "cursor" {
+        t.Errorf("Expected validation error on cursor, got %v", err)
+    }
+}
+
+func TestUserService_UpdateUser_DuplicateEmail(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    maria, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "Maria Santos", Email: "maria@email.com"})
+    
+    // Act
+    _, err := service.UpdateUser(ctx, maria.ID, dto.UpdateUserRequest{Name: "Maria Santos", Email: "joao@email.com"}, dto.Precondition{})
+    
+    // Assert
+    if !errors.Is(err, entity.ErrEmailTaken) {
+        t.Errorf("Expected ErrEmailTaken, got %v", err)
+    }
+}
+
+func TestUserService_SelfCanOnlyAccessOwnRecord(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    joao, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    maria, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "Maria Santos", Email: "maria@email.com"})
+    
+    selfCtx := auth.WithPrincipal(context.Background(), &auth.Principal{ID: joao.ID, Roles: []string{auth.RoleSelf}})
+    
+    // Act & Assert
+    if _, err := service.GetUserByID(selfCtx, joao.ID); err != nil {
+        t.Errorf("Expected to read own record, got %v", err)
+    }
+    if _, err := service.UpdateUser(selfCtx, joao.ID, dto.UpdateUserRequest{Name: "João Santos", Email: "joao@email.com"}, dto.Precondition{}); err != nil {
+        t.Errorf("Expected to update own record, got %v", err)
+    }
+    if _, err := service.GetUserByID(selfCtx, maria.ID); !errors.Is(err, auth.ErrForbidden) {
+        t.Errorf("Expected ErrForbidden reading another user, got %v", err)
+    }
+    if err := service.DeleteUser(selfCtx, joao.ID, dto.Precondition{}); !errors.Is(err, auth.ErrForbidden) {
+        t.Errorf("Expected ErrForbidden deleting, got %v", err)
+    }
+    if _, err := service.GetAllUsers(selfCtx, dto.ListUsersQuery{}); !errors.Is(err, auth.ErrForbidden) {
+        t.Errorf("Expected ErrForbidden listing, got %v", err)
+    }
+}
+
+func TestUserService_RequiresPrincipal(t *testing.T) {
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    
+    _, err := service.GetAllUsers(context.Background(), dto.ListUsersQuery{})
+    
+    if !errors.Is(err, auth.ErrUnauthenticated) {
+        t.Errorf("Expected ErrUnauthenticated, got %v", err)
+    }
+}
+
+// adminContext simula uma requisição autenticada como administrador
+func adminContext() context.Context {
+    return auth.WithPrincipal(context.Background(), &auth.Principal{ID: "test-admin", Roles: []string{auth.RoleAdmin}})
+}
+
+func TestUserService_CreateUser_WeakPassword(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    
+    // Act
+    _, err := service.CreateUser(adminContext(), dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com", Password: "curta"})
+    
+    // Assert
+    var validationErr *entity.ValidationError
+    if !errors.As(err, &validationErr) {
+        t.Fatalf("Expected ValidationError, got %v", err)
+    }
+    if validationErr.Fields[0].Field != "password" {
+        t.Errorf("Expected password field error, got %+v", validationErr.Fields)
+    }
+}
+
+func TestUserService_DeleteUser_IsSoftAndRestorable(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    user, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    if err := service.DeleteUser(ctx, user.ID, dto.Precondition{}); err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    list, _ := service.GetAllUsers(ctx, dto.ListUsersQuery{})
+    if len(list.Data) != 0 {
+        t.Errorf("Expected deleted user to be hidden from list, got %d users", len(list.Data))
+    }
+    if err := service.DeleteUser(ctx, user.ID, dto.Precondition{}); !errors.Is(err, entity.ErrUserNotFound) {
+        t.Errorf("Expected ErrUserNotFound deleting twice, got %v", err)
+    }
+    
+    // Act
+    restored, err := service.RestoreUser(ctx, user.ID)
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if restored.ID != user.ID {
+        t.Errorf("Expected restored user %s, got %s", user.ID, restored.ID)
+    }
+    if _, err := service.GetUserByID(ctx, user.ID); err != nil {
+        t.Errorf("Expected restored user to be visible, got %v", err)
+    }
+    if _, err := service.RestoreUser(ctx, user.ID); !errors.Is(err, entity.ErrUserNotFound) {
+        t.Errorf("Expected ErrUserNotFound restoring an active user, got %v", err)
+    }
+}
+
+func TestUserService_RestoreUser_EmailReused(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    deleted, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    _ = service.DeleteUser(ctx, deleted.ID, dto.Precondition{})
+    
+    // O email de um excluído fica livre para um novo cadastro
+    if _, err := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Santos", Email: "joao@email.com"}); err != nil {
+        t.Fatalf("Expected email of deleted user to be reusable, got %v", err)
+    }
+    
+    // Act
+    _, err := service.RestoreUser(ctx, deleted.ID)
+    
+    // Assert
+    if !errors.Is(err, entity.ErrEmailTaken) {
+        t.Errorf("Expected ErrEmailTaken, got %v", err)
+    }
+}
+
+func TestUserService_RestoreAndPurge_RequireAdmin(t *testing.T) {
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    
+    user, _ := service.CreateUser(adminContext(), dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    _ = service.DeleteUser(adminContext(), user.ID, dto.Precondition{})
+    
+    operatorCtx := auth.WithPrincipal(context.Background(), &auth.Principal{ID: "ops", Roles: []string{auth.RoleOperator}})
+    if _, err := service.RestoreUser(operatorCtx, user.ID); !errors.Is(err, auth.ErrForbidden) {
+        t.Errorf("Expected ErrForbidden restoring as operator, got %v", err)
+    }
+    if err := service.PurgeUser(operatorCtx, user.ID, dto.Precondition{}); !errors.Is(err, auth.ErrForbidden) {
+        t.Errorf("Expected ErrForbidden purging as operator, got %v", err)
+    }
+}
+
+func TestUserService_UpdateUser_IfMatch(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    user, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    ifMatch := dto.Precondition{Present: true, IfMatch: []int64{user.Version}}
+    
+    // Act
+    updated, err := service.UpdateUser(ctx, user.ID, dto.UpdateUserRequest{Name: "João Santos", Email: "joao@email.com"}, ifMatch)
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error with current version, got %v", err)
+    }
+    if updated.Version != user.Version+1 {
+        t.Errorf("Expected version %d, got %d", user.Version+1, updated.Version)
+    }
+    if _, err := service.UpdateUser(ctx, user.ID, dto.UpdateUserRequest{Name: "João Souza", Email: "joao@email.com"}, ifMatch); !errors.Is(err, entity.ErrPreconditionFailed) {
+        t.Errorf("Expected ErrPreconditionFailed with stale version, got %v", err)
+    }
+    if err := service.DeleteUser(ctx, user.ID, ifMatch); !errors.Is(err, entity.ErrPreconditionFailed) {
+        t.Errorf("Expected ErrPreconditionFailed deleting with stale version, got %v", err)
+    }
+}
+
+func TestUserService_PatchUser(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    
+    user, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    mergePatch := func(doc string) dto.PatchUserRequest {
+        return dto.PatchUserRequest{ContentType: jsonpatch.MediaTypeMergePatch, Document: []byte(doc)}
+    }
+    jsonPatch := func(doc string) dto.PatchUserRequest {
+        return dto.PatchUserRequest{ContentType: jsonpatch.MediaTypeJSONPatch, Document: []byte(doc)}
+    }
+    
+    // Act
+    patched, err := service.PatchUser(ctx, user.ID, mergePatch(`{"name":"João Santos"}`), dto.Precondition{})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if patched.Name != "João Santos" || patched.Email != "joao@email.com" {
+        t.Errorf("Expected only the name to change, got %+v", patched)
+    }
+    
+    patched, err = service.PatchUser(ctx, user.ID, jsonPatch(`[
+        {"op":"test","path":"/name","value":"João Santos"},
+        {"op":"replace","path":"/email","value":"joao.santos@email.com"}
+    ]`), dto.Precondition{})
+    if err != nil {
+        t.Fatalf("Expected JSON Patch to apply, got %v", err)
+    }
+    if patched.Email != "joao.santos@email.com" {
+        t.Errorf("Expected email joao.santos@email.com, got %s", patched.Email)
+    }
+    
+    var validationErr *entity.ValidationError
+    if _, err := service.PatchUser(ctx, user.ID, mergePatch(`{"name":null}`), dto.Precondition{}); !errors.As(err, &validationErr) {
+        t.Errorf("Expected ValidationError clearing the name, got %v", err)
+    }
+    if _, err := service.PatchUser(ctx, user.ID, mergePatch(`{"email":"not-an-email"}`), dto.Precondition{}); !errors.As(err, &validationErr) {
+        t.Errorf("Expected ValidationError for an invalid email, got %v", err)
+    }
+    if _, err := service.PatchUser(ctx, user.ID, mergePatch(`{"id":"other"}`), dto.Precondition{}); !errors.As(err, &validationErr) {
+        t.Errorf("Expected ValidationError changing a read-only field, got %v", err)
+    }
+    if _, err := service.PatchUser(ctx, user.ID, jsonPatch(`[{"op":"test","path":"/name","value":"João Silva"}]`), dto.Precondition{}); !errors.Is(err, jsonpatch.ErrTestFailed) {
+        t.Errorf("Expected ErrTestFailed, got %v", err)
+    }
+    if stored, _ := service.GetUserByID(ctx, user.ID); stored.Name != "João Santos" || stored.Email != "joao.santos@email.com" {
+        t.Errorf("Expected failed patches to leave the user untouched, got %+v", stored)
+    }
+}
+
+func TestUserService_SubscribeChanges_ReceivesCreateUpdateDelete(t *testing.T) {
+    // Arrange
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    ctx := adminContext()
+    sub, err := service.SubscribeChanges(ctx, "")
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    defer sub.Close()
+    
+    // Act
+    user, _ := service.CreateUser(ctx, dto.CreateUserRequest{Name: "João Silva", Email: "joao@email.com"})
+    _, _ = service.UpdateUser(ctx, user.ID, dto.UpdateUserRequest{Name: "João Santos", Email: "joao@email.com"}, dto.Precondition{})
+    _ = service.DeleteUser(ctx, user.ID, dto.Precondition{})
+    
+    // Assert
+    var types, ids []string
+    for i := 0; i < 3; i++ {
+        event := <-sub.Events()
+        types = append(types, event.Type)
+        ids = append(ids, event.ID)
+        if !strings.Contains(string(event.Data), user.ID) {
+            t.Errorf("Expected %s data to carry the user id, got %s", event.Type, event.Data)
+        }
+    }
+    if got := strings.Join(types, ","); got != "user.created,user.updated,user.deleted" {
+        t.Errorf("Expected created, updated and deleted, got %s", got)
+    }
+    
+    // Retomando do primeiro evento, os outros dois vêm do buffer
+    resumed, _ := service.SubscribeChanges(ctx, ids[0])
+    defer resumed.Close()
+    if len(resumed.Replay) != 2 || resumed.Replay[0].ID != ids[1] || resumed.Replay[1].ID != ids[2] {
+        t.Errorf("Expected to replay %v, got %+v", ids[1:], resumed.Replay)
+    }
+}
+
+func TestUserService_SubscribeChanges_RequiresListPermission(t *testing.T) {
+    repo := repository.NewUserRepository(repository.InMemory, nil)
+    service := NewUserService(repo)
+    selfCtx := auth.WithPrincipal(context.Background(), &auth.Principal{ID: "user-1", Roles: []string{auth.RoleSelf}})
+    
+    _, err := service.SubscribeChanges(selfCtx, "")
+    
+    if !errors.Is(err, auth.ErrForbidden) {
+        t.Errorf("Expected ErrForbidden, got %v", err)
+    }
+}
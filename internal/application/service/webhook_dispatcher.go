@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/webhook"
+)
+
+const (
+    DefaultWebhookInterval    = time.Second
+    DefaultWebhookBatchSize   = 20
+    DefaultWebhookMaxAttempts = 8
+    DefaultWebhookBaseBackoff = 10 * time.Second
+    DefaultWebhookMaxBackoff  = time.Hour
+)
+
+// WebhookDispatcher faz as chamadas das entregas agendadas pelo
+// WebhookService. Uma falha é repetida com espera exponencial e jitter, para
+// que um destino fora do ar não receba todas as tentativas ao mesmo tempo
+// quando voltar; depois de maxAttempts a entrega é dada como falha.
+type WebhookDispatcher struct {
+    webhooks    repository.WebhookRepository
+    deliveries  repository.WebhookDeliveryRepository
+    sender      webhook.Sender
+    interval    time.Duration
+    batchSize   int
+    maxAttempts int
+    baseBackoff time.Duration
+    maxBackoff  time.Duration
+    lease       time.Duration
+    logger      *slog.Logger
+}
+
+type DispatcherOption func(*WebhookDispatcher)
+
+func WithDispatchInterval(interval time.Duration) DispatcherOption {
+    return func(d *WebhookDispatcher) {
+        d.interval = interval
+    }
+}
+
+func WithDispatchBatchSize(size int) DispatcherOption {
+    return func(d *WebhookDispatcher) {
+        d.batchSize = size
+    }
+}
+
+// WithDispatchRetries define quantas tentativas cada entrega tem e a espera
+// entre elas, que dobra a cada falha a partir de base até maxBackoff.
+func WithDispatchRetries(maxAttempts int, base, maxBackoff time.Duration) DispatcherOption {
+    return func(d *WebhookDispatcher) {
+        d.maxAttempts = maxAttempts
+        d.baseBackoff = base
+        d.maxBackoff = maxBackoff
+    }
+}
+
+// WithDispatchLease define por quanto tempo um lote fica reservado; precisa
+// cobrir o lote inteiro chamando destinos que só respondem no timeout.
+func WithDispatchLease(lease time.Duration) DispatcherOption {
+    return func(d *WebhookDispatcher) {
+        d.lease = lease
+    }
+}
+
+func WithDispatchLogger(logger *slog.Logger) DispatcherOption {
+    return func(d *WebhookDispatcher) {
+        d.logger = logger
+    }
+}
+
+func NewWebhookDispatcher(webhooks repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository, sender webhook.Sender, opts ...DispatcherOption) *WebhookDispatcher {
+    d := &WebhookDispatcher{
+        webhooks:    webhooks,
+        deliveries:  deliveries,
+        sender:      sender,
+        interval:    DefaultWebhookInterval,
+        batchSize:   DefaultWebhookBatchSize,
+        maxAttempts: DefaultWebhookMaxAttempts,
+        baseBackoff: DefaultWebhookBaseBackoff,
+        maxBackoff:  DefaultWebhookMaxBackoff,
+        logger:      slog.Default(),
+    }
+    for _, opt := range opts {
+        opt(d)
+    }
+    if d.interval <= 0 {
+        d.interval = DefaultWebhookInterval
+    }
+    if d.lease <= 0 {
+        d.lease = time.Duration(d.batchSize) * webhook.DefaultTimeout
+    }
+    return d
+}
+
+// Run faz as entregas vencidas e repete a cada intervalo até o contexto ser
+// cancelado. Enquanto houver lotes cheios, não espera.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+    ticker := time.NewTicker(d.interval)
+    defer ticker.Stop()
+
+    for {
+        claimed, err := d.DispatchOnce(ctx)
+        if err != nil && ctx.Err() == nil {
+            d.logger.ErrorContext(ctx, "failed to dispatch webhooks", "error", err)
+        }
+        if err == nil && claimed == d.batchSize {
+            continue
+        }
+
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+// DispatchOnce reserva um lote de entregas e tenta cada uma. Devolve
+// quantas foram reservadas.
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (_ int, err error) {
+    ctx, span := tracer.Start(ctx, "WebhookDispatcher.DispatchOnce")
+    defer func() { endSpan(span, err) }()
+
+    deliveries, err := d.deliveries.ClaimDue(ctx, time.Now(), d.lease, d.batchSize)
+    if err != nil {
+        return 0, err
+    }
+
+    // O lote costuma ter várias entregas para a mesma inscrição
+    webhooks := make(map[string]*entity.Webhook)
+    for _, delivery := range deliveries {
+        w, cached := webhooks[delivery.WebhookID]
+        if !cached {
+            if w, err = d.webhooks.FindByID(ctx, delivery.WebhookID); err != nil {
+                return len(deliveries), err
+            }
+            webhooks[delivery.WebhookID] = w
+        }
+
+        d.attempt(ctx, w, delivery)
+        // Sem gravar o resultado, a reserva expira e a entrega é repetida
+        if err := d.deliveries.RecordAttempts(ctx, delivery); err != nil {
+            return len(deliveries), err
+        }
+    }
+    return len(deliveries), nil
+}
+
+func (d *WebhookDispatcher) attempt(ctx context.Context, w *entity.Webhook, delivery *entity.WebhookDelivery) {
+    logAttrs := []any{"webhook_id", delivery.WebhookID, "delivery_id", delivery.ID, "event_type", delivery.EventType}
+
+    // Inscrição apagada ou desativada depois do agendamento: não chama ninguém
+    if w == nil || !w.Active {
+        delivery.Fail(entity.WebhookAttempt{At: time.Now(), Error: "webhook removed or disabled"})
+        d.logger.WarnContext(ctx, "webhook delivery dropped", logAttrs...)
+        return
+    }
+
+    start := time.Now()
+    status, err := d.sender.Send(ctx, webhook.Request{
+        URL:        w.URL,
+        Secret:     w.Secret,
+        DeliveryID: delivery.ID,
+        EventType:  delivery.EventType,
+        Body:       delivery.Payload,
+    })
+    attempt := entity.WebhookAttempt{StatusCode: status, Duration: time.Since(start), At: start}
+
+    switch {
+    case err == nil:
+        delivery.Succeed(attempt)
+        d.logger.DebugContext(ctx, "webhook delivered", append(logAttrs, "status", status)...)
+    case delivery.Attempts+1 >= d.maxAttempts:
+        attempt.Error = err.Error()
+        delivery.Fail(attempt)
+        d.logger.ErrorContext(ctx, "webhook delivery failed permanently",
+            append(logAttrs, "attempts", delivery.Attempts, "status", status, "error", err)...)
+    default:
+        attempt.Error = err.Error()
+        delivery.Retry(attempt, time.Now().Add(d.backoff(delivery.Attempts+1)))
+        d.logger.WarnContext(ctx, "webhook delivery failed",
+            append(logAttrs, "attempts", delivery.Attempts, "status", status, "error", err)...)
+    }
+}
+
+// backoff devolve a espera depois de uma falha na tentativa número attempt
+// (começando em 1): metade fixa e metade sorteada de uma espera exponencial.
+func (d *WebhookDispatcher) backoff(attempt int) time.Duration {
+    delay := d.baseBackoff
+    for i := 1; i < attempt && delay < d.maxBackoff; i++ {
+        delay *= 2
+    }
+    delay = min(delay, d.maxBackoff)
+    if half := delay / 2; half > 0 {
+        return half + rand.N(delay-half)
+    }
+    return delay
+}
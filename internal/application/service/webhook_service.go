@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/events"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+)
+
+// WebhookService administra as inscrições de webhook e, como Publisher do
+// relay, transforma cada evento em uma entrega por inscrição interessada.
+// Quem faz as chamadas HTTP é o WebhookDispatcher.
+type WebhookService struct {
+    webhooks   repository.WebhookRepository
+    deliveries repository.WebhookDeliveryRepository
+    policy     auth.Policy
+    logger     *slog.Logger
+}
+
+var _ events.Publisher = (*WebhookService)(nil)
+
+type WebhookOption func(*WebhookService)
+
+// WithWebhookPolicy troca as regras de autorização (o padrão é auth.RolePolicy).
+func WithWebhookPolicy(policy auth.Policy) WebhookOption {
+    return func(s *WebhookService) {
+        s.policy = policy
+    }
+}
+
+func WithWebhookLogger(logger *slog.Logger) WebhookOption {
+    return func(s *WebhookService) {
+        s.logger = logger
+    }
+}
+
+func NewWebhookService(webhooks repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository, opts ...WebhookOption) *WebhookService {
+    s := &WebhookService{
+        webhooks:   webhooks,
+        deliveries: deliveries,
+        policy:     auth.RolePolicy{},
+        logger:     slog.Default(),
+    }
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, req dto.CreateWebhookRequest) (_ *dto.WebhookResponse, err error) {
+    ctx, span := tracer.Start(ctx, "WebhookService.CreateWebhook")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.authorize(ctx); err != nil {
+        return nil, err
+    }
+
+    webhook, err := entity.NewWebhook(req.URL, req.Secret, req.Events, req.Description)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.webhooks.Save(ctx, webhook); err != nil {
+        return nil, err
+    }
+
+    s.logger.InfoContext(ctx, "webhook created", "webhook_id", webhook.ID, "events", webhook.Events)
+    response := toWebhookResponse(webhook)
+    response.Secret = webhook.Secret
+    return response, nil
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id string) (_ *dto.WebhookResponse, err error) {
+    ctx, span := tracer.Start(ctx, "WebhookService.GetWebhook")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.authorize(ctx); err != nil {
+        return nil, err
+    }
+
+    webhook, err := s.find(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    return toWebhookResponse(webhook), nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context) (_ *dto.WebhookListResponse, err error) {
+    ctx, span := tracer.Start(ctx, "WebhookService.ListWebhooks")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.authorize(ctx); err != nil {
+        return nil, err
+    }
+
+    webhooks, err := s.webhooks.FindAll(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    response := &dto.WebhookListResponse{Data: []*dto.WebhookResponse{}}
+    for _, w := range webhooks {
+        response.Data = append(response.Data, toWebhookResponse(w))
+    }
+    return response, nil
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id string, req dto.UpdateWebhookRequest) (_ *dto.WebhookResponse, err error) {
+    ctx, span := tracer.Start(ctx, "WebhookService.UpdateWebhook")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.authorize(ctx); err != nil {
+        return nil, err
+    }
+
+    webhook, err := s.find(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    active := webhook.Active
+    if req.Active != nil {
+        active = *req.Active
+    }
+    if err := webhook.Update(req.URL, req.Events, req.Description, active); err != nil {
+        return nil, err
+    }
+    if err := s.webhooks.Save(ctx, webhook); err != nil {
+        return nil, err
+    }
+
+    s.logger.InfoContext(ctx, "webhook updated", "webhook_id", webhook.ID, "active", webhook.Active)
+    return toWebhookResponse(webhook), nil
+}
+
+// DeleteWebhook remove a inscrição; as entregas pendentes não são feitas.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) (err error) {
+    ctx, span := tracer.Start(ctx, "WebhookService.DeleteWebhook")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.authorize(ctx); err != nil {
+        return err
+    }
+
+    if err := s.webhooks.Delete(ctx, id); err != nil {
+        return err
+    }
+
+    s.logger.InfoContext(ctx, "webhook deleted", "webhook_id", id)
+    return nil
+}
+
+// ListDeliveries pagina as entregas da inscrição, da mais nova para a mais antiga.
+func (s *WebhookService) ListDeliveries(ctx context.Context, webhookID string, query dto.ListDeliveriesQuery) (_ *dto.WebhookDeliveryListResponse, err error) {
+    ctx, span := tracer.Start(ctx, "WebhookService.ListDeliveries")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.authorize(ctx); err != nil {
+        return nil, err
+    }
+    if _, err := s.find(ctx, webhookID); err != nil {
+        return nil, err
+    }
+
+    opts := repository.DeliveryListOptions{
+        Limit:  query.Limit,
+        Status: entity.WebhookDeliveryStatus(query.Status),
+    }
+    if opts.Limit <= 0 {
+        opts.Limit = DefaultPageSize
+    }
+    if opts.Limit > MaxPageSize {
+        opts.Limit = MaxPageSize
+    }
+    if query.Cursor != "" {
+        cursor, err := repository.DecodeCursor(query.Cursor)
+        if err != nil {
+            return nil, entity.NewValidationError("cursor", entity.CodeInvalid, err.Error())
+        }
+        // As entregas só são listadas da mais nova para a mais antiga
+        if cursor.Sort != repository.SortDesc {
+            return nil, entity.NewValidationError("cursor", entity.CodeInvalid, repository.ErrCursorSortMismatch.Error())
+        }
+        opts.After = cursor
+    }
+
+    // Busca um item a mais para saber se existe próxima página
+    limit := opts.Limit
+    opts.Limit++
+    deliveries, err := s.deliveries.FindByWebhook(ctx, webhookID, opts)
+    if err != nil {
+        return nil, err
+    }
+
+    response := &dto.WebhookDeliveryListResponse{Data: []*dto.WebhookDeliveryResponse{}}
+    if len(deliveries) > limit {
+        deliveries = deliveries[:limit]
+        last := deliveries[len(deliveries)-1]
+        response.NextCursor = repository.Cursor{CreatedAt: last.CreatedAt, ID: last.ID, Sort: repository.SortDesc}.Encode()
+    }
+    for _, d := range deliveries {
+        response.Data = append(response.Data, toDeliveryResponse(d))
+    }
+    return response, nil
+}
+
+// GetDelivery devolve a entrega com todas as tentativas feitas.
+func (s *WebhookService) GetDelivery(ctx context.Context, webhookID, id string) (_ *dto.WebhookDeliveryResponse, err error) {
+    ctx, span := tracer.Start(ctx, "WebhookService.GetDelivery")
+    defer func() { endSpan(span, err) }()
+
+    if err := s.authorize(ctx); err != nil {
+        return nil, err
+    }
+    if _, err := s.find(ctx, webhookID); err != nil {
+        return nil, err
+    }
+
+    delivery, err := s.deliveries.FindByID(ctx, webhookID, id)
+    if err != nil {
+        return nil, err
+    }
+    if delivery == nil {
+        return nil, entity.ErrWebhookDeliveryNotFound
+    }
+    return toDeliveryResponse(delivery), nil
+}
+
+// Publish agenda uma entrega do evento para cada inscrição ativa que o
+// assina. Um erro faz o relay publicar o evento de novo; as entregas já
+// agendadas não se repetem.
+func (s *WebhookService) Publish(ctx context.Context, msg events.Message) (err error) {
+    ctx, span := tracer.Start(ctx, "WebhookService.Publish")
+    defer func() { endSpan(span, err) }()
+
+    webhooks, err := s.webhooks.FindAll(ctx)
+    if err != nil {
+        return err
+    }
+
+    var deliveries []*entity.WebhookDelivery
+    var payload []byte
+    for _, w := range webhooks {
+        if !w.Subscribes(msg.Type) {
+            continue
+        }
+        if payload == nil {
+            if payload, err = json.Marshal(msg); err != nil {
+                return err
+            }
+        }
+        deliveries = append(deliveries, entity.NewWebhookDelivery(w.ID, msg.ID, msg.Type, payload))
+    }
+    return s.deliveries.Add(ctx, deliveries...)
+}
+
+func (s *WebhookService) find(ctx context.Context, id string) (*entity.Webhook, error) {
+    webhook, err := s.webhooks.FindByID(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    if webhook == nil {
+        return nil, entity.ErrWebhookNotFound
+    }
+    return webhook, nil
+}
+
+func (s *WebhookService) authorize(ctx context.Context) error {
+    principal, _ := auth.PrincipalFromContext(ctx)
+    return s.policy.Authorize(principal, auth.ActionManageWebhooks, "")
+}
+
+// toWebhookResponse nunca inclui o segredo; só CreateWebhook o devolve.
+func toWebhookResponse(w *entity.Webhook) *dto.WebhookResponse {
+    response := &dto.WebhookResponse{
+        ID:          w.ID,
+        URL:         w.URL,
+        Events:      w.Events,
+        Description: w.Description,
+        Active:      w.Active,
+        CreatedAt:   w.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+        UpdatedAt:   w.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+    }
+    if response.Events == nil {
+        response.Events = []string{}
+    }
+    return response
+}
+
+func toDeliveryResponse(d *entity.WebhookDelivery) *dto.WebhookDeliveryResponse {
+    response := &dto.WebhookDeliveryResponse{
+        ID:             d.ID,
+        EventID:        d.EventID,
+        EventType:      d.EventType,
+        Status:         string(d.Status),
+        Attempts:       d.Attempts,
+        LastStatusCode: d.LastStatusCode,
+        LastError:      d.LastError,
+        CreatedAt:      d.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+    }
+    if d.Status == entity.DeliveryPending {
+        response.NextAttemptAt = d.NextAttemptAt.Format("2006-01-02T15:04:05Z07:00")
+    }
+    if d.DeliveredAt != nil {
+        response.DeliveredAt = d.DeliveredAt.Format("2006-01-02T15:04:05Z07:00")
+    }
+    for _, a := range d.History {
+        response.AttemptLog = append(response.AttemptLog, dto.WebhookAttemptResponse{
+            Number:     a.Number,
+            StatusCode: a.StatusCode,
+            Error:      a.Error,
+            DurationMs: a.Duration.Milliseconds(),
+            At:         a.At.Format("2006-01-02T15:04:05Z07:00"),
+        })
+    }
+    return response
+}
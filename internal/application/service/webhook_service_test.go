@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/events"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/webhook"
+)
+
+func newWebhookTestService() (*WebhookService, repository.WebhookRepository, repository.WebhookDeliveryRepository) {
+    webhooks := repository.NewWebhookRepository(repository.InMemory, nil)
+    deliveries := repository.NewWebhookDeliveryRepository(repository.InMemory, nil)
+    return NewWebhookService(webhooks, deliveries), webhooks, deliveries
+}
+
+func userCreatedMessage(id string) events.Message {
+    return events.Message{
+        ID:          id,
+        Type:        entity.EventUserCreated,
+        AggregateID: "user-1",
+        OccurredAt:  time.Now(),
+        Data:        []byte(`{"user_id":"user-1"}`),
+    }
+}
+
+func TestWebhookService_CreateWebhook_Validation(t *testing.T) {
+    service, _, _ := newWebhookTestService()
+    
+    tests := []struct {
+        name  string
+        req   dto.CreateWebhookRequest
+        field string
+        code  string
+    }{
+        {"relative url", dto.CreateWebhookRequest{URL: "/hooks"}, "url", entity.CodeInvalid},
+        {"unsupported scheme", dto.CreateWebhookRequest{URL: "ftp://example.com/hooks"}, "url", entity.CodeInvalid},
+        {"unknown event", dto.CreateWebhookRequest{URL: "https://example.com/hooks", Events: []string{"user.renamed"}}, "events", entity.CodeInvalid},
+        {"short secret", dto.CreateWebhookRequest{URL: "https://example.com/hooks", Secret: "curto"}, "secret", entity.CodeTooShort},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := service.CreateWebhook(adminContext(), tt.req)
+            
+            var validationErr *entity.ValidationError
+            if !errors.As(err, &validationErr) {
+                t.Fatalf("Expected ValidationError, got %v", err)
+            }
+            if got := validationErr.Fields[0]; got.Field != tt.field || got.Code != tt.code {
+                t.Errorf("Expected %s/%s, got %s/%s", tt.field, tt.code, got.Field, got.Code)
+            }
+        })
+    }
+}
+
+func TestWebhookService_CreateWebhook_SecretOnlyShownOnCreation(t *testing.T) {
+    // Arrange
+    service, _, _ := newWebhookTestService()
+    
+    // Act
+    created, err := service.CreateWebhook(adminContext(), dto.CreateWebhookRequest{URL: "https://example.com/hooks"})
+    fetched, _ := service.GetWebhook(adminContext(), created.ID)
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if !strings.HasPrefix(created.Secret, "whsec_") {
+        t.Errorf("Expected generated secret, got %q", created.Secret)
+    }
+    if fetched.Secret != "" {
+        t.Errorf("Expected secret to be hidden after creation, got %q", fetched.Secret)
+    }
+}
+
+func TestWebhookService_RequiresAdmin(t *testing.T) {
+    service, _, _ := newWebhookTestService()
+    ctx := auth.WithPrincipal(context.Background(), &auth.Principal{ID: "operator", Roles: []string{auth.RoleOperator}})
+    
+    _, err := service.CreateWebhook(ctx, dto.CreateWebhookRequest{URL: "https://example.com/hooks"})
+    
+    if !errors.Is(err, auth.ErrForbidden) {
+        t.Errorf("Expected ErrForbidden, got %v", err)
+    }
+}
+
+func TestWebhookService_Publish_SchedulesOneDeliveryPerSubscriber(t *testing.T) {
+    // Arrange
+    service, _, _ := newWebhookTestService()
+    ctx := adminContext()
+    all, _ := service.CreateWebhook(ctx, dto.CreateWebhookRequest{URL: "https://a.example.com/hooks"})
+    deletedOnly, _ := service.CreateWebhook(ctx, dto.CreateWebhookRequest{URL: "https://b.example.com/hooks", Events: []string{entity.EventUserDeleted}})
+    disabled, _ := service.CreateWebhook(ctx, dto.CreateWebhookRequest{URL: "https://c.example.com/hooks"})
+    inactive := false
+    _, _ = service.UpdateWebhook(ctx, disabled.ID, dto.UpdateWebhookRequest{URL: disabled.URL, Active: &inactive})
+    
+    // Act: o relay pode publicar o mesmo evento mais de uma vez
+    errFirst := service.Publish(ctx, userCreatedMessage("event-1"))
+    errSecond := service.Publish(ctx, userCreatedMessage("event-1"))
+    
+    // Assert
+    if errFirst != nil || errSecond != nil {
+        t.Fatalf("Expected no errors, got %v and %v", errFirst, errSecond)
+    }
+    for id, want := range map[string]int{all.ID: 1, deletedOnly.ID: 0, disabled.ID: 0} {
+        deliveries, err := service.ListDeliveries(ctx, id, dto.ListDeliveriesQuery{})
+        if err != nil {
+            t.Fatalf("Expected no error, got %v", err)
+        }
+        if len(deliveries.Data) != want {
+            t.Errorf("Webhook %s: expected %d deliveries, got %d", id, want, len(deliveries.Data))
+        }
+    }
+}
+
+func TestWebhookDispatcher_DeliversSignedPayload(t *testing.T) {
+    // Arrange
+    service, webhooks, deliveries := newWebhookTestService()
+    ctx := adminContext()
+    var verifyErr error
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        body, _ := io.ReadAll(r.Body)
+        verifyErr = webhook.Verify("whsec_segredo-do-parceiro", r.Header.Get(webhook.HeaderSignature),
+            r.Header.Get(webhook.HeaderTimestamp), body, webhook.DefaultTolerance, time.Now())
+        w.WriteHeader(http.StatusOK)
+    }))
+    defer server.Close()
+    created, _ := service.CreateWebhook(ctx, dto.CreateWebhookRequest{URL: server.URL, Secret: "whsec_segredo-do-parceiro"})
+    _ = service.Publish(ctx, userCreatedMessage("event-1"))
+    dispatcher := NewWebhookDispatcher(webhooks, deliveries, webhook.NewClient(time.Second))
+    
+    // Act
+    claimed, err := dispatcher.DispatchOnce(context.Background())
+    
+    // Assert
+    if err != nil || claimed != 1 {
+        t.Fatalf("Expected 1 delivery without error, got %d and %v", claimed, err)
+    }
+    if verifyErr != nil {
+        t.Errorf("Expected valid signature, got %v", verifyErr)
+    }
+    list, _ := service.ListDeliveries(ctx, created.ID, dto.ListDeliveriesQuery{})
+    if got := list.Data[0]; got.Status != string(entity.DeliverySucceeded) || got.LastStatusCode != http.StatusOK {
+        t.Errorf("Expected succeeded delivery with 200, got %+v", got)
+    }
+}
+
+func TestWebhookDispatcher_RetriesThenFails(t *testing.T) {
+    // Arrange
+    service, webhooks, deliveries := newWebhookTestService()
+    ctx := adminContext()
+    var calls atomic.Int32
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        calls.Add(1)
+        w.WriteHeader(http.StatusServiceUnavailable)
+    }))
+    defer server.Close()
+    created, _ := service.CreateWebhook(ctx, dto.CreateWebhookRequest{URL: server.URL})
+    _ = service.Publish(ctx, userCreatedMessage("event-1"))
+    dispatcher := NewWebhookDispatcher(webhooks, deliveries, webhook.NewClient(time.Second), WithDispatchRetries(3, 0, 0))
+    
+    // Act
+    var claimed []int
+    for i := 0; i < 4; i++ {
+        n, err := dispatcher.DispatchOnce(context.Background())
+        if err != nil {
+            t.Fatalf("Expected no error, got %v", err)
+        }
+        claimed = append(claimed, n)
+    }
+    
+    // Assert
+    if calls.Load() != 3 || claimed[3] != 0 {
+        t.Errorf("Expected 3 calls and nothing left to claim, got %d calls and %v", calls.Load(), claimed)
+    }
+    list, _ := service.ListDeliveries(ctx, created.ID, dto.ListDeliveriesQuery{Status: string(entity.DeliveryFailed)})
+    if len(list.Data) != 1 {
+        t.Fatalf("Expected 1 failed delivery, got %d", len(list.Data))
+    }
+    delivery, err := service.GetDelivery(ctx, created.ID, list.Data[0].ID)
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if len(delivery.AttemptLog) != 3 || delivery.AttemptLog[2].Number != 3 || delivery.AttemptLog[2].StatusCode != http.StatusServiceUnavailable {
+        t.Errorf("Expected 3 recorded attempts ending in 503, got %+v", delivery.AttemptLog)
+    }
+}
+
+func TestWebhookDispatcher_BackoffIsJitteredExponential(t *testing.T) {
+    dispatcher := NewWebhookDispatcher(nil, nil, nil, WithDispatchRetries(10, 10*time.Second, time.Minute))
+    
+    for attempt, ceiling := range map[int]time.Duration{1: 10 * time.Second, 2: 20 * time.Second, 3: 40 * time.Second, 6: time.Minute} {
+        for i := 0; i < 50; i++ {
+            if got := dispatcher.backoff(attempt); got < ceiling/2 || got >= ceiling {
+                t.Fatalf("backoff(%d): expected within [%s, %s), got %s", attempt, ceiling/2, ceiling, got)
+            }
+        }
+    }
+}
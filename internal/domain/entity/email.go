@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"net/mail"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+const (
+    maxEmailLength    = 254
+    maxEmailLocalPart = 64
+)
+
+// Email é um endereço já validado. String() devolve o endereço como será
+// guardado e exibido: a parte local como o usuário digitou e o domínio em
+// minúsculas e ASCII (punycode). Canonical() é a forma usada para garantir
+// que cada caixa de correio tenha um só usuário.
+type Email struct {
+    address   string
+    canonical string
+}
+
+func (e Email) String() string {
+    return e.address
+}
+
+func (e Email) Canonical() string {
+    return e.canonical
+}
+
+func (e Email) IsZero() bool {
+    return e.address == ""
+}
+
+// EmailPolicy decide como endereços são normalizados e quais são aceitos.
+type EmailPolicy struct {
+    // NormalizeProviders aplica as regras de provedores conhecidos na forma
+    // canônica; hoje, no Gmail, pontos e o sufixo +tag são ignorados
+    NormalizeProviders bool
+    // BlockedDomains recusa cadastros nesses domínios e em seus subdomínios
+    // (ex.: serviços de email descartável)
+    BlockedDomains []string
+}
+
+// DefaultEmailPolicy não normaliza provedores nem bloqueia domínios.
+var DefaultEmailPolicy = EmailPolicy{}
+
+// Parse valida o endereço (addr-spec da RFC 5322, sem nome de exibição) e
+// calcula a forma canônica. Use para buscas; para gravar, use Accept.
+func (p EmailPolicy) Parse(raw string) (Email, error) {
+    raw = strings.TrimSpace(raw)
+    if raw == "" {
+        return Email{}, NewValidationError("email", CodeRequired, "email is required")
+    }
+    invalid := NewValidationError("email", CodeInvalid, "email is invalid")
+    
+    addr, err := mail.ParseAddress(raw)
+    if err != nil || addr.Name != "" || strings.ContainsAny(raw, "<>") {
+        return Email{}, invalid
+    }
+    
+    at := strings.LastIndex(addr.Address, "@")
+    local, domain := addr.Address[:at], addr.Address[at+1:]
+    
+    domain, err = idna.Lookup.ToASCII(domain)
+    if err != nil || !strings.Contains(domain, ".") || strings.HasSuffix(domain, ".") {
+        return Email{}, invalid
+    }
+    if len(local) > maxEmailLocalPart || len(local)+1+len(domain) > maxEmailLength {
+        return Email{}, invalid
+    }
+    // O ParseAddress tira as aspas da parte local ("joao silva"@...); se ela
+    // só é válida entre aspas, o endereço guardado não seria mais um email
+    if _, err := mail.ParseAddress(local + "@" + domain); err != nil {
+        return Email{}, invalid
+    }
+    
+    canonicalLocal, canonicalDomain := strings.ToLower(local), domain
+    if p.NormalizeProviders {
+        canonicalLocal, canonicalDomain = normalizeProvider(canonicalLocal, canonicalDomain)
+    }
+    
+    return Email{
+        address:   local + "@" + domain,
+        canonical: canonicalLocal + "@" + canonicalDomain,
+    }, nil
+}
+
+// Accept é Parse mais as regras para endereços que vão ser gravados, como o
+// bloqueio de domínios.
+func (p EmailPolicy) Accept(raw string) (Email, error) {
+    email, err := p.Parse(raw)
+    if err != nil {
+        return Email{}, err
+    }
+    
+    domain := email.address[strings.LastIndex(email.address, "@")+1:]
+    for _, blocked := range p.BlockedDomains {
+        blocked = strings.ToLower(strings.TrimSpace(blocked))
+        if blocked != "" && (domain == blocked || strings.HasSuffix(domain, "."+blocked)) {
+            return Email{}, NewValidationError("email", CodeBlockedDomain, "email domain is not allowed")
+        }
+    }
+    return email, nil
+}
+
+// normalizeProvider aplica as regras do Gmail, onde j.o.a.o+x@gmail.com e
+// joao@googlemail.com são a mesma caixa.
+func normalizeProvider(local, domain string) (string, string) {
+    switch domain {
+    case "gmail.com", "googlemail.com":
+        local, _, _ = strings.Cut(local, "+")
+        return strings.ReplaceAll(local, ".", ""), "gmail.com"
+    }
+    return local, domain
+}
@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEmailPolicy_Parse(t *testing.T) {
+    cases := []struct {
+        name      string
+        raw       string
+        address   string
+        canonical string
+    }{
+        {"plain", "joao@email.com", "joao@email.com", "joao@email.com"},
+        {"trims spaces", "  joao@email.com ", "joao@email.com", "joao@email.com"},
+        {"folds domain case only", "Joao.Silva@EMAIL.Com", "Joao.Silva@email.com", "joao.silva@email.com"},
+        {"unicode domain to punycode", "joao@exämple.com", "joao@xn--exmple-cua.com", "joao@xn--exmple-cua.com"},
+        {"punycode stays as is", "joao@xn--exmple-cua.com", "joao@xn--exmple-cua.com", "joao@xn--exmple-cua.com"},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            email, err := DefaultEmailPolicy.Parse(tc.raw)
+
+            if err != nil {
+                t.Fatalf("Expected %q to be valid, got %v", tc.raw, err)
+            }
+            if email.String() != tc.address {
+                t.Errorf("Expected address %q, got %q", tc.address, email.String())
+            }
+            if email.Canonical() != tc.canonical {
+                t.Errorf("Expected canonical %q, got %q", tc.canonical, email.Canonical())
+            }
+        })
+    }
+}
+
+func TestEmailPolicy_Parse_PunycodeRoundTrip(t *testing.T) {
+    // Arrange
+    unicode, err := DefaultEmailPolicy.Parse("joão@exämple.com")
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    // Act
+    reparsed, err := DefaultEmailPolicy.Parse(unicode.String())
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected the stored form to parse again, got %v", err)
+    }
+    if reparsed != unicode {
+        t.Errorf("Expected %+v after the round trip, got %+v", unicode, reparsed)
+    }
+}
+
+func TestEmailPolicy_Parse_Rejects(t *testing.T) {
+    cases := []struct {
+        name string
+        raw  string
+        code string
+    }{
+        {"empty", "   ", CodeRequired},
+        {"display name", "João Silva <joao@email.com>", CodeInvalid},
+        {"quoted display name", `"João Silva" <joao@email.com>`, CodeInvalid},
+        {"angle brackets only", "<joao@email.com>", CodeInvalid},
+        {"trailing comment", "joao@email.com (João Silva)", CodeInvalid},
+        {"leading comment", "(João Silva) joao@email.com", CodeInvalid},
+        {"missing at", "joao.email.com", CodeInvalid},
+        {"domain without dot", "joao@localhost", CodeInvalid},
+        {"domain with trailing dot", "joao@email.com.", CodeInvalid},
+        {"local part that needs quotes", `"joao silva"@email.com`, CodeInvalid},
+        {"two addresses", "joao@email.com, maria@email.com", CodeInvalid},
+        {"local part too long", strings.Repeat("a", maxEmailLocalPart+1) + "@email.com", CodeInvalid},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            _, err := DefaultEmailPolicy.Parse(tc.raw)
+
+            var validationErr *ValidationError
+            if !errors.As(err, &validationErr) {
+                t.Fatalf("Expected ValidationError for %q, got %v", tc.raw, err)
+            }
+            if f := validationErr.Fields[0]; f.Field != "email" || f.Code != tc.code {
+                t.Errorf("Expected email/%s, got %s/%s", tc.code, f.Field, f.Code)
+            }
+        })
+    }
+}
+
+func TestEmailPolicy_NormalizeProviders(t *testing.T) {
+    policy := EmailPolicy{NormalizeProviders: true}
+    
+    cases := []struct {
+        name      string
+        raw       string
+        address   string
+        canonical string
+    }{
+        {"gmail dots and tag", "J.o.a.o+news@gmail.com", "J.o.a.o+news@gmail.com", "joao@gmail.com"},
+        {"googlemail alias", "joao@googlemail.com", "joao@googlemail.com", "joao@gmail.com"},
+        {"googlemail dots and tag", "jo.ao+x@GoogleMail.com", "jo.ao+x@googlemail.com", "joao@gmail.com"},
+        {"other domain keeps dots", "jo.ao@email.com", "jo.ao@email.com", "jo.ao@email.com"},
+        {"other domain keeps tag", "joao+news@email.com", "joao+news@email.com", "joao+news@email.com"},
+        {"gmail subdomain is not gmail", "jo.ao+x@mail.gmail.com", "jo.ao+x@mail.gmail.com", "jo.ao+x@mail.gmail.com"},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            email, err := policy.Parse(tc.raw)
+
+            if err != nil {
+                t.Fatalf("Expected %q to be valid, got %v", tc.raw, err)
+            }
+            if email.String() != tc.address {
+                t.Errorf("Expected address %q, got %q", tc.address, email.String())
+            }
+            if email.Canonical() != tc.canonical {
+                t.Errorf("Expected canonical %q, got %q", tc.canonical, email.Canonical())
+            }
+        })
+    }
+    
+    // Sem a opção, o Gmail é tratado como qualquer outro domínio
+    if email, _ := DefaultEmailPolicy.Parse("j.oao+x@gmail.com"); email.Canonical() != "j.oao+x@gmail.com" {
+        t.Errorf("Expected no provider rules by default, got %q", email.Canonical())
+    }
+}
+
+func TestEmailPolicy_Accept_BlockedDomains(t *testing.T) {
+    policy := EmailPolicy{BlockedDomains: []string{" Mailinator.com ", "tempmail.io"}}
+    
+    cases := []struct {
+        name    string
+        raw     string
+        blocked bool
+    }{
+        {"blocked domain", "joao@mailinator.com", true},
+        {"blocked regardless of case", "joao@MAILINATOR.COM", true},
+        {"subdomain of blocked domain", "joao@eu.mailinator.com", true},
+        {"deep subdomain of blocked domain", "joao@a.b.tempmail.io", true},
+        {"domain that only ends with the same text", "joao@notmailinator.com", false},
+        {"blocked name as a subdomain elsewhere", "joao@mailinator.com.br", false},
+        {"unrelated domain", "joao@email.com", false},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            _, err := policy.Accept(tc.raw)
+
+            var validationErr *ValidationError
+            blocked := errors.As(err, &validationErr) && validationErr.Fields[0].Code == CodeBlockedDomain
+            if blocked != tc.blocked {
+                t.Errorf("Expected blocked=%v for %q, got %v", tc.blocked, tc.raw, err)
+            }
+            if !tc.blocked && err != nil {
+                t.Errorf("Expected %q to be accepted, got %v", tc.raw, err)
+            }
+        })
+    }
+    
+    // Parse serve para buscas e não aplica o bloqueio
+    if _, err := policy.Parse("joao@mailinator.com"); err != nil {
+        t.Errorf("Expected Parse to ignore blocked domains, got %v", err)
+    }
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+    ErrUserNotFound   = errors.New("user not found")
+    ErrEmailTaken     = errors.New("email already exists")
+    ErrUserNotDeleted = errors.New("user is not deleted")
+
+    ErrEmailAlreadyVerified = errors.New("email already verified")
+    // ErrInvalidToken cobre token inexistente, expirado ou já usado, sem
+    // revelar qual dos casos aconteceu.
+    ErrInvalidToken = errors.New("invalid or expired token")
+
+    ErrVersionConflict    = errors.New("version conflict")
+    ErrPreconditionFailed = errors.New("precondition failed")
+
+    ErrWebhookNotFound         = errors.New("webhook not found")
+    ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// VersionConflictError indica que o registro mudou desde que foi lido:
+// a versão salva não é mais Version.
+type VersionConflictError struct {
+    ID      string
+    Version int64
+}
+
+func (e *VersionConflictError) Error() string {
+    return fmt.Sprintf("user %s was modified concurrently (expected version %d)", e.ID, e.Version)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+    return target == ErrVersionConflict
+}
+
+// EmailTakenError é devolvido pelo repositório quando o Save violaria a
+// unicidade do email entre os usuários ativos. Email é a forma canônica.
+type EmailTakenError struct {
+    Email string
+}
+
+func (e *EmailTakenError) Error() string {
+    return ErrEmailTaken.Error()
+}
+
+func (e *EmailTakenError) Is(target error) bool {
+    return target == ErrEmailTaken
+}
+
+// Códigos estáveis das regras violadas; o cliente deve se guiar por eles,
+// não pelo texto da mensagem.
+const (
+    CodeRequired         = "required"
+    CodeInvalid          = "invalid"
+    CodeReadOnly         = "read_only"
+    CodeBlockedDomain    = "blocked_domain"
+    CodeTooShort         = "too_short"
+    CodeTooLong          = "too_long"
+    CodeMissingUppercase = "missing_uppercase"
+    CodeMissingLowercase = "missing_lowercase"
+    CodeMissingDigit     = "missing_digit"
+    CodeMissingSymbol    = "missing_symbol"
+)
+
+type FieldError struct {
+    Field   string
+    Code    string
+    Message string
+}
+
+// ValidationError agrupa as regras de negócio violadas, campo a campo.
+type ValidationError struct {
+    Fields []FieldError
+}
+
+func NewValidationError(field, code, message string) *ValidationError {
+    return &ValidationError{Fields: []FieldError{{Field: field, Code: code, Message: message}}}
+}
+
+func (e *ValidationError) Error() string {
+    messages := make([]string, 0, len(e.Fields))
+    for _, f := range e.Fields {
+        messages = append(messages, f.Message)
+    }
+    return strings.Join(messages, "; ")
+}
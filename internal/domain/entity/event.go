@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// Tipos dos eventos de domínio; são os nomes vistos por quem consome o outbox.
+const (
+    EventUserCreated      = "user.created"
+    EventUserEmailChanged = "user.email_changed"
+    EventUserDeleted      = "user.deleted"
+)
+
+// EventTypes lista todos os tipos acima, na ordem em que são documentados.
+var EventTypes = []string{EventUserCreated, EventUserEmailChanged, EventUserDeleted}
+
+// Event é um fato que já aconteceu com um agregado. A entidade acumula os
+// eventos que gera e o serviço os grava no outbox na mesma transação da
+// alteração.
+type Event interface {
+    EventType() string
+    AggregateID() string
+    OccurredAt() time.Time
+}
+
+type UserCreated struct {
+    UserID string    `json:"user_id"`
+    Name   string    `json:"name"`
+    Email  string    `json:"email"`
+    At     time.Time `json:"occurred_at"`
+}
+
+func (e UserCreated) EventType() string     { return EventUserCreated }
+func (e UserCreated) AggregateID() string   { return e.UserID }
+func (e UserCreated) OccurredAt() time.Time { return e.At }
+
+type UserEmailChanged struct {
+    UserID   string    `json:"user_id"`
+    OldEmail string    `json:"old_email"`
+    NewEmail string    `json:"new_email"`
+    At       time.Time `json:"occurred_at"`
+}
+
+func (e UserEmailChanged) EventType() string     { return EventUserEmailChanged }
+func (e UserEmailChanged) AggregateID() string   { return e.UserID }
+func (e UserEmailChanged) OccurredAt() time.Time { return e.At }
+
+// UserDeleted corresponde à exclusão lógica, ou ao expurgo de um usuário
+// ainda ativo; expurgar quem já foi excluído não gera outro evento.
+type UserDeleted struct {
+    UserID string    `json:"user_id"`
+    At     time.Time `json:"occurred_at"`
+}
+
+func (e UserDeleted) EventType() string     { return EventUserDeleted }
+func (e UserDeleted) AggregateID() string   { return e.UserID }
+func (e UserDeleted) OccurredAt() time.Time { return e.At }
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TokenPurpose string
+
+const (
+    PurposeEmailVerification TokenPurpose = "email_verification"
+    PurposePasswordReset     TokenPurpose = "password_reset"
+)
+
+// OneTimeToken é um token de uso único enviado por email. Assim como o
+// RefreshToken, só o hash é guardado. Email registra a forma canônica do
+// endereço para o qual o token foi enviado, para que trocar o email invalide
+// tokens antigos (mas não uma edição que só muda maiúsculas).
+type OneTimeToken struct {
+    ID        string
+    UserID    string
+    Purpose   TokenPurpose
+    TokenHash string
+    Email     string
+    ExpiresAt time.Time
+    CreatedAt time.Time
+    UsedAt    *time.Time
+}
+
+func NewOneTimeToken(userID string, purpose TokenPurpose, tokenHash, email string, ttl time.Duration) *OneTimeToken {
+    now := time.Now()
+    return &OneTimeToken{
+        ID:        uuid.New().String(),
+        UserID:    userID,
+        Purpose:   purpose,
+        TokenHash: tokenHash,
+        Email:     email,
+        ExpiresAt: now.Add(ttl),
+        CreatedAt: now,
+    }
+}
+
+// Usable indica se o token ainda pode ser consumido.
+func (t *OneTimeToken) Usable(now time.Time) bool {
+    return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxStatus string
+
+const (
+    OutboxPending   OutboxStatus = "pending"
+    OutboxDelivered OutboxStatus = "delivered"
+    // OutboxDead é a fila de mensagens mortas: esgotaram as tentativas e
+    // só voltam a ser entregues por intervenção manual
+    OutboxDead OutboxStatus = "dead"
+)
+
+// OutboxMessage é um evento de domínio esperando entrega. É gravado na
+// mesma transação da alteração que o gerou e entregue depois pelo relay,
+// pelo menos uma vez: o consumidor deve descartar IDs repetidos.
+type OutboxMessage struct {
+    ID            string
+    EventType     string
+    AggregateID   string
+    Payload       []byte
+    OccurredAt    time.Time
+    Status        OutboxStatus
+    Attempts      int
+    NextAttemptAt time.Time
+    LastError     string
+    CreatedAt     time.Time
+    DeliveredAt   *time.Time
+}
+
+func NewOutboxMessage(event Event) (*OutboxMessage, error) {
+    payload, err := json.Marshal(event)
+    if err != nil {
+        return nil, fmt.Errorf("encode %s event: %w", event.EventType(), err)
+    }
+    now := time.Now()
+    return &OutboxMessage{
+        ID:            uuid.New().String(),
+        EventType:     event.EventType(),
+        AggregateID:   event.AggregateID(),
+        Payload:       payload,
+        OccurredAt:    event.OccurredAt(),
+        Status:        OutboxPending,
+        NextAttemptAt: now,
+        CreatedAt:     now,
+    }, nil
+}
+
+func (m *OutboxMessage) MarkDelivered(now time.Time) {
+    m.Status = OutboxDelivered
+    m.Attempts++
+    m.DeliveredAt = &now
+    m.LastError = ""
+}
+
+// MarkFailed registra a tentativa que falhou e agenda a próxima para retryAt.
+func (m *OutboxMessage) MarkFailed(err error, retryAt time.Time) {
+    m.Attempts++
+    m.LastError = err.Error()
+    m.NextAttemptAt = retryAt
+}
+
+// MarkDead registra a última tentativa e tira a mensagem da fila de entrega.
+func (m *OutboxMessage) MarkDead(err error) {
+    m.Status = OutboxDead
+    m.Attempts++
+    m.LastError = err.Error()
+}
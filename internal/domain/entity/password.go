@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordHasher gera e confere hashes de senha. A implementação (argon2id,
+// bcrypt) fica na infraestrutura; o domínio só guarda o hash codificado.
+type PasswordHasher interface {
+    Hash(password string) (string, error)
+    Verify(hash, password string) (bool, error)
+}
+
+// PasswordPolicy descreve as regras mínimas de uma senha aceitável.
+type PasswordPolicy struct {
+    MinLength     int
+    MaxLength     int
+    RequireUpper  bool
+    RequireLower  bool
+    RequireDigit  bool
+    RequireSymbol bool
+}
+
+// DefaultPasswordPolicy é a política aplicada por SetPassword. MaxLength
+// existe porque o custo do hash cresce com o tamanho da entrada.
+var DefaultPasswordPolicy = PasswordPolicy{
+    MinLength:    8,
+    MaxLength:    128,
+    RequireUpper: true,
+    RequireLower: true,
+    RequireDigit: true,
+}
+
+// Validate devolve um ValidationError com todas as regras violadas.
+func (p PasswordPolicy) Validate(password string) error {
+    var upper, lower, digit, symbol bool
+    for _, r := range password {
+        switch {
+        case unicode.IsUpper(r):
+            upper = true
+        case unicode.IsLower(r):
+            lower = true
+        case unicode.IsDigit(r):
+            digit = true
+        case unicode.IsPunct(r) || unicode.IsSymbol(r):
+            symbol = true
+        }
+    }
+
+    length := utf8.RuneCountInString(password)
+    var fields []FieldError
+    add := func(code, message string) {
+        fields = append(fields, FieldError{Field: "password", Code: code, Message: message})
+    }
+
+    if length < p.MinLength {
+        add(CodeTooShort, "password is too short")
+    }
+    if p.MaxLength > 0 && length > p.MaxLength {
+        add(CodeTooLong, "password is too long")
+    }
+    if p.RequireUpper && !upper {
+        add(CodeMissingUppercase, "password must contain an uppercase letter")
+    }
+    if p.RequireLower && !lower {
+        add(CodeMissingLowercase, "password must contain a lowercase letter")
+    }
+    if p.RequireDigit && !digit {
+        add(CodeMissingDigit, "password must contain a digit")
+    }
+    if p.RequireSymbol && !symbol {
+        add(CodeMissingSymbol, "password must contain a symbol")
+    }
+
+    if len(fields) > 0 {
+        return &ValidationError{Fields: fields}
+    }
+    return nil
+}
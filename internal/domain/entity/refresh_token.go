@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken é uma sessão de login. Só o hash do token opaco é guardado;
+// o valor em si é entregue uma única vez ao cliente.
+type RefreshToken struct {
+    ID        string
+    UserID    string
+    TokenHash string
+    ExpiresAt time.Time
+    CreatedAt time.Time
+    RevokedAt *time.Time
+}
+
+func NewRefreshToken(userID, tokenHash string, ttl time.Duration) *RefreshToken {
+    now := time.Now()
+    return &RefreshToken{
+        ID:        uuid.New().String(),
+        UserID:    userID,
+        TokenHash: tokenHash,
+        ExpiresAt: now.Add(ttl),
+        CreatedAt: now,
+    }
+}
+
+func (t *RefreshToken) Revoked() bool {
+    return t.RevokedAt != nil
+}
+
+func (t *RefreshToken) Expired(now time.Time) bool {
+    return !now.Before(t.ExpiresAt)
+}
+
+func (t *RefreshToken) Revoke(now time.Time) {
+    if t.RevokedAt == nil {
+        t.RevokedAt = &now
+    }
+}
@@ -1,53 +1,173 @@
 package entity
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type User struct {
-    ID        string    `json:"id"`
-    Name      string    `json:"name"`
-    Email     string    `json:"email"`
-    CreatedAt time.Time `json:"created_at"`
-    UpdatedAt time.Time `json:"updated_at"`
+    ID              string     `json:"id"`
+    Name            string     `json:"name"`
+    Email           string     `json:"email"`
+    // NormalizedEmail é a forma canônica (Email.Canonical), única entre os
+    // usuários ativos
+    NormalizedEmail string     `json:"-"`
+    // PasswordHash fica vazio para usuários sem login por senha
+    PasswordHash    string     `json:"-"`
+    // EmailVerifiedAt é nulo até o dono confirmar o email atual
+    EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+    CreatedAt       time.Time  `json:"created_at"`
+    UpdatedAt       time.Time  `json:"updated_at"`
+    // DeletedAt marca a exclusão lógica; o registro some das buscas até ser
+    // restaurado ou expurgado
+    DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+    // Version é incrementada pelo repositório a cada Save; zero significa
+    // que o usuário ainda não foi persistido
+    Version         int64      `json:"version"`
+    
+    // events guarda os eventos gerados desde a última gravação
+    events []Event
 }
 
-func NewUser(name, email string) (*User, error){
+// UserOption configura campos opcionais na criação do usuário.
+type UserOption func(*User) error
+
+// WithPassword define a senha inicial, validada pela DefaultPasswordPolicy.
+func WithPassword(password string, hasher PasswordHasher) UserOption {
+    return func(u *User) error {
+        return u.SetPassword(password, hasher)
+    }
+}
+
+func NewUser(name string, email Email, opts ...UserOption) (*User, error){
 	if name == "" {
-		return nil, errors.New("name is required")
+		return nil, NewValidationError("name", CodeRequired, "name is required")
 	}
-	if email == "" {
-		return nil, errors.New("email is required")
+	if email.IsZero() {
+		return nil, NewValidationError("email", CodeRequired, "email is required")
 	}
 
 	now := time.Now()
 
-	return &User{
+	user := &User{
 		ID: 			uuid.New().String(),
 		Name: name,
-		Email: email,
+		Email: email.String(),
+		NormalizedEmail: email.Canonical(),
 		CreatedAt: now,
 		UpdatedAt: now,
-	}, nil
+	}
+	for _, opt := range opts {
+		if err := opt(user); err != nil {
+			return nil, err
+		}
+	}
+	user.raise(UserCreated{UserID: user.ID, Name: user.Name, Email: user.Email, At: now})
+	return user, nil
 }
 
 func (u *User) UpdateName(name string) error {
 	if name == "" {
-		return errors.New("name is required")
+		return NewValidationError("name", CodeRequired, "name is required")
 	}
 	u.Name = name
 	u.UpdatedAt = time.Now()
 	return nil
 }
 
-func (u *User) UpdateEmail(email string) error {
-	if email == "" {
-		return errors.New("email is required")
+// UpdateEmail troca o endereço. A verificação só se perde se a caixa de
+// correio mudar, não numa simples diferença de maiúsculas.
+func (u *User) UpdateEmail(email Email) error {
+	if email.IsZero() {
+		return NewValidationError("email", CodeRequired, "email is required")
 	}
-	u.Email = email
-	u.UpdatedAt = time.Now()
+	if email.Canonical() != u.NormalizedEmail {
+		u.EmailVerifiedAt = nil
+	}
+	now := time.Now()
+	if email.String() != u.Email {
+		u.raise(UserEmailChanged{UserID: u.ID, OldEmail: u.Email, NewEmail: email.String(), At: now})
+	}
+	u.Email = email.String()
+	u.NormalizedEmail = email.Canonical()
+	u.UpdatedAt = now
 	return nil
 }
+
+func (u *User) SetPassword(password string, hasher PasswordHasher) error {
+    if err := DefaultPasswordPolicy.Validate(password); err != nil {
+        return err
+    }
+    hash, err := hasher.Hash(password)
+    if err != nil {
+        return err
+    }
+    u.PasswordHash = hash
+    u.UpdatedAt = time.Now()
+    return nil
+}
+
+func (u *User) HasPassword() bool {
+    return u.PasswordHash != ""
+}
+
+// CheckPassword confere a senha contra o hash salvo. Usuários sem senha
+// nunca passam.
+func (u *User) CheckPassword(password string, hasher PasswordHasher) (bool, error) {
+    if !u.HasPassword() {
+        return false, nil
+    }
+    return hasher.Verify(u.PasswordHash, password)
+}
+
+func (u *User) IsEmailVerified() bool {
+    return u.EmailVerifiedAt != nil
+}
+
+func (u *User) MarkEmailVerified(now time.Time) error {
+    if u.IsEmailVerified() {
+        return ErrEmailAlreadyVerified
+    }
+    u.EmailVerifiedAt = &now
+    u.UpdatedAt = now
+    return nil
+}
+
+func (u *User) IsDeleted() bool {
+    return u.DeletedAt != nil
+}
+
+func (u *User) SoftDelete(now time.Time) error {
+    if u.IsDeleted() {
+        return ErrUserNotFound
+    }
+    u.DeletedAt = &now
+    u.UpdatedAt = now
+    u.raise(UserDeleted{UserID: u.ID, At: now})
+    return nil
+}
+
+func (u *User) Restore(now time.Time) error {
+    if !u.IsDeleted() {
+        return ErrUserNotDeleted
+    }
+    u.DeletedAt = nil
+    u.UpdatedAt = now
+    return nil
+}
+
+// Events devolve os eventos gerados desde a última chamada a ClearEvents.
+func (u *User) Events() []Event {
+    return u.events
+}
+
+// ClearEvents descarta os eventos pendentes, depois que foram gravados no
+// outbox.
+func (u *User) ClearEvents() {
+    u.events = nil
+}
+
+func (u *User) raise(event Event) {
+    u.events = append(u.events, event)
+}
@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MinWebhookSecretLength é o tamanho mínimo de um segredo escolhido pelo
+// cliente; os gerados pela API têm 32 bytes aleatórios.
+const MinWebhookSecretLength = 16
+
+// webhookSecretPrefix identifica os segredos gerados, como em outros provedores.
+const webhookSecretPrefix = "whsec_"
+
+// Webhook é uma inscrição de um parceiro nos eventos de usuário. Secret fica
+// guardado em claro porque é a chave do HMAC de cada entrega; só é mostrado
+// ao cliente na criação.
+type Webhook struct {
+    ID          string
+    URL         string
+    Secret      string
+    // Events filtra os tipos entregues; vazio recebe todos
+    Events      []string
+    Description string
+    Active      bool
+    CreatedAt   time.Time
+    UpdatedAt   time.Time
+}
+
+// NewWebhook cria uma inscrição ativa. Sem secret, um é gerado.
+func NewWebhook(rawURL, secret string, events []string, description string) (*Webhook, error) {
+    if err := validateWebhookURL(rawURL); err != nil {
+        return nil, err
+    }
+    if err := validateWebhookEvents(events); err != nil {
+        return nil, err
+    }
+    if secret == "" {
+        generated, err := generateWebhookSecret()
+        if err != nil {
+            return nil, err
+        }
+        secret = generated
+    }
+    if len(secret) < MinWebhookSecretLength {
+        return nil, NewValidationError("secret", CodeTooShort, "secret is too short")
+    }
+
+    now := time.Now()
+    return &Webhook{
+        ID:          uuid.New().String(),
+        URL:         rawURL,
+        Secret:      secret,
+        Events:      slices.Clone(events),
+        Description: description,
+        Active:      true,
+        CreatedAt:   now,
+        UpdatedAt:   now,
+    }, nil
+}
+
+// Update substitui a configuração da inscrição; o segredo não muda.
+func (w *Webhook) Update(rawURL string, events []string, description string, active bool) error {
+    if err := validateWebhookURL(rawURL); err != nil {
+        return err
+    }
+    if err := validateWebhookEvents(events); err != nil {
+        return err
+    }
+    w.URL = rawURL
+    w.Events = slices.Clone(events)
+    w.Description = description
+    w.Active = active
+    w.UpdatedAt = time.Now()
+    return nil
+}
+
+// Subscribes diz se eventos do tipo informado devem ser entregues.
+func (w *Webhook) Subscribes(eventType string) bool {
+    return w.Active && (len(w.Events) == 0 || slices.Contains(w.Events, eventType))
+}
+
+func validateWebhookURL(rawURL string) error {
+    if rawURL == "" {
+        return NewValidationError("url", CodeRequired, "url is required")
+    }
+    u, err := url.Parse(rawURL)
+    if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+        return NewValidationError("url", CodeInvalid, "url must be an absolute http or https URL")
+    }
+    return nil
+}
+
+func validateWebhookEvents(events []string) error {
+    for _, event := range events {
+        if !slices.Contains(EventTypes, event) {
+            return NewValidationError("events", CodeInvalid, "unknown event type "+event)
+        }
+    }
+    return nil
+}
+
+func generateWebhookSecret() (string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+    DeliveryPending   WebhookDeliveryStatus = "pending"
+    DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+    // DeliveryFailed é definitivo: as tentativas acabaram ou a inscrição
+    // foi desativada antes da entrega
+    DeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery é a entrega de um evento a uma inscrição. Existe no
+// máximo uma por par (WebhookID, EventID), mesmo que o relay publique o
+// evento mais de uma vez.
+type WebhookDelivery struct {
+    ID             string
+    WebhookID      string
+    EventID        string
+    EventType      string
+    // Payload é o corpo enviado, igual em todas as tentativas
+    Payload        []byte
+    Status         WebhookDeliveryStatus
+    Attempts       int
+    NextAttemptAt  time.Time
+    LastStatusCode int
+    LastError      string
+    CreatedAt      time.Time
+    DeliveredAt    *time.Time
+    // History só é carregado na busca de uma entrega específica; nas
+    // entregas reservadas para envio, guarda as tentativas ainda não gravadas
+    History        []WebhookAttempt
+}
+
+// WebhookAttempt é uma chamada à URL da inscrição. StatusCode é zero quando
+// não houve resposta (timeout, conexão recusada etc.).
+type WebhookAttempt struct {
+    Number     int
+    StatusCode int
+    Error      string
+    Duration   time.Duration
+    At         time.Time
+}
+
+func NewWebhookDelivery(webhookID, eventID, eventType string, payload []byte) *WebhookDelivery {
+    now := time.Now()
+    return &WebhookDelivery{
+        ID:            uuid.New().String(),
+        WebhookID:     webhookID,
+        EventID:       eventID,
+        EventType:     eventType,
+        Payload:       payload,
+        Status:        DeliveryPending,
+        NextAttemptAt: now,
+        CreatedAt:     now,
+    }
+}
+
+// Succeed registra a tentativa que foi aceita pelo destino.
+func (d *WebhookDelivery) Succeed(attempt WebhookAttempt) {
+    d.record(attempt)
+    d.Status = DeliverySucceeded
+    d.DeliveredAt = &attempt.At
+}
+
+// Retry registra a tentativa que falhou e agenda a próxima para retryAt.
+func (d *WebhookDelivery) Retry(attempt WebhookAttempt, retryAt time.Time) {
+    d.record(attempt)
+    d.NextAttemptAt = retryAt
+}
+
+// Fail registra a última tentativa; a entrega não é mais tentada.
+func (d *WebhookDelivery) Fail(attempt WebhookAttempt) {
+    d.record(attempt)
+    d.Status = DeliveryFailed
+}
+
+func (d *WebhookDelivery) record(attempt WebhookAttempt) {
+    d.Attempts++
+    attempt.Number = d.Attempts
+    d.LastStatusCode = attempt.StatusCode
+    d.LastError = attempt.Error
+    d.History = append(d.History, attempt)
+}
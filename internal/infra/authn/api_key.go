@@ -0,0 +1,84 @@
+package authn
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+)
+
+type apiKey struct {
+    name  string
+    hash  []byte
+    roles []string
+}
+
+// APIKeyVerifier valida chaves estáticas. Só o SHA-256 das chaves fica em
+// memória e na configuração, nunca o valor em texto puro.
+type APIKeyVerifier struct {
+    keys []apiKey
+}
+
+// ParseAPIKeys lê entradas no formato "nome:sha256hex[:papel1|papel2]".
+func ParseAPIKeys(entries []string) (*APIKeyVerifier, error) {
+    v := &APIKeyVerifier{}
+    
+    for _, entry := range entries {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        
+        parts := strings.Split(entry, ":")
+        if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+            return nil, fmt.Errorf("invalid api key entry %q (expected name:sha256hex[:roles])", entry)
+        }
+        
+        hash, err := hex.DecodeString(parts[1])
+        if err != nil || len(hash) != sha256.Size {
+            return nil, fmt.Errorf("api key %q: hash must be a hex encoded SHA-256", parts[0])
+        }
+        
+        key := apiKey{name: parts[0], hash: hash}
+        if len(parts) == 3 && parts[2] != "" {
+            key.roles = strings.Split(parts[2], "|")
+        }
+        v.keys = append(v.keys, key)
+    }
+    
+    return v, nil
+}
+
+func (v *APIKeyVerifier) Empty() bool {
+    return len(v.keys) == 0
+}
+
+func (v *APIKeyVerifier) Verify(presented string) (*auth.Principal, error) {
+    sum := sha256.Sum256([]byte(presented))
+    
+    // Compara com todas as chaves para não vazar pelo tempo qual delas casou
+    var match *apiKey
+    for i := range v.keys {
+        if subtle.ConstantTimeCompare(sum[:], v.keys[i].hash) == 1 {
+            match = &v.keys[i]
+        }
+    }
+    if match == nil {
+        return nil, fmt.Errorf("%w: invalid api key", auth.ErrUnauthenticated)
+    }
+    
+    return &auth.Principal{
+        ID:     match.name,
+        Method: auth.MethodAPIKey,
+        Roles:  match.roles,
+    }, nil
+}
+
+// HashAPIKey gera o valor a ser colocado em AUTH_API_KEYS para uma chave.
+func HashAPIKey(key string) string {
+    sum := sha256.Sum256([]byte(key))
+    return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,35 @@
+package authn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// Authenticator aceita uma API key no header X-API-Key ou um JWT no
+// header Authorization: Bearer.
+type Authenticator struct {
+    apiKeys *APIKeyVerifier
+    jwt     *JWTVerifier
+}
+
+func NewAuthenticator(apiKeys *APIKeyVerifier, jwt *JWTVerifier) *Authenticator {
+    return &Authenticator{apiKeys: apiKeys, jwt: jwt}
+}
+
+func (a *Authenticator) Authenticate(r *http.Request) (*auth.Principal, error) {
+    if key := r.Header.Get(APIKeyHeader); key != "" && a.apiKeys != nil && !a.apiKeys.Empty() {
+        return a.apiKeys.Verify(key)
+    }
+    
+    header := r.Header.Get("Authorization")
+    if token, ok := strings.CutPrefix(header, "Bearer "); ok && a.jwt != nil && a.jwt.Enabled() {
+        return a.jwt.Verify(strings.TrimSpace(token))
+    }
+    
+    return nil, fmt.Errorf("%w: missing credentials", auth.ErrUnauthenticated)
+}
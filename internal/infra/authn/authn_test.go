@@ -0,0 +1,159 @@
+package authn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestAPIKeyVerifier_Verify(t *testing.T) {
+    // Arrange
+    verifier, err := ParseAPIKeys([]string{"ci:" + HashAPIKey("s3cret") + ":admin|operator"})
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    // Act
+    principal, err := verifier.Verify("s3cret")
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected key to be accepted, got %v", err)
+    }
+    if principal.ID != "ci" || !principal.HasRole("operator") {
+        t.Errorf("Unexpected principal %+v", principal)
+    }
+    
+    if _, err := verifier.Verify("wrong"); !errors.Is(err, auth.ErrUnauthenticated) {
+        t.Errorf("Expected ErrUnauthenticated, got %v", err)
+    }
+}
+
+func TestParseAPIKeys_RejectsPlainTextKeys(t *testing.T) {
+    if _, err := ParseAPIKeys([]string{"ci:s3cret"}); err == nil {
+        t.Error("Expected error for non-hashed key")
+    }
+}
+
+func TestJWTVerifier_HS256(t *testing.T) {
+    // Arrange
+    secret := []byte("test-secret")
+    verifier := NewJWTVerifier(JWTConfig{HMACSecret: secret})
+    
+    valid := signHS256(t, secret, Claims{
+        Roles:            []string{"admin"},
+        RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+    })
+    expired := signHS256(t, secret, Claims{
+        RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))},
+    })
+    forged := signHS256(t, []byte("other-secret"), Claims{
+        RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+    })
+    
+    // Act & Assert
+    principal, err := verifier.Verify(valid)
+    if err != nil {
+        t.Fatalf("Expected valid token, got %v", err)
+    }
+    if principal.ID != "user-1" || !principal.HasRole("admin") {
+        t.Errorf("Unexpected principal %+v", principal)
+    }
+    
+    for name, token := range map[string]string{"expired": expired, "forged": forged} {
+        if _, err := verifier.Verify(token); !errors.Is(err, auth.ErrUnauthenticated) {
+            t.Errorf("Expected %s token to be rejected, got %v", name, err)
+        }
+    }
+}
+
+func TestJWTVerifier_JWKS(t *testing.T) {
+    // Arrange
+    privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+    jwks, err := ParseJWKS([]byte(fmt.Sprintf(`{"keys":[{"kty":"EC","kid":"k1","crv":"P-256","x":%q,"y":%q}]}`,
+        base64.RawURLEncoding.EncodeToString(privateKey.X.FillBytes(make([]byte, 32))),
+        base64.RawURLEncoding.EncodeToString(privateKey.Y.FillBytes(make([]byte, 32))),
+    )))
+    if err != nil {
+        t.Fatalf("Expected JWKS to parse, got %v", err)
+    }
+    verifier := NewJWTVerifier(JWTConfig{JWKS: jwks})
+    
+    token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+        Subject:   "user-2",
+        ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+    })
+    token.Header["kid"] = "k1"
+    signed, err := token.SignedString(privateKey)
+    if err != nil {
+        t.Fatal(err)
+    }
+    
+    // Act
+    principal, err := verifier.Verify(signed)
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected token signed by JWKS key to be accepted, got %v", err)
+    }
+    if principal.ID != "user-2" {
+        t.Errorf("Expected subject user-2, got %s", principal.ID)
+    }
+}
+
+func TestTokenIssuer_IssuesTokensAcceptedByVerifier(t *testing.T) {
+    // Arrange
+    config := JWTConfig{HMACSecret: []byte("test-secret"), Issuer: "user-api", Audience: "user-api"}
+    issuer, err := NewTokenIssuer(config, time.Minute)
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    // Act
+    token, expiresAt, err := issuer.Issue("user-1", []string{auth.RoleSelf})
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if time.Until(expiresAt) > time.Minute {
+        t.Errorf("Expected token to expire within a minute, got %v", expiresAt)
+    }
+    principal, err := NewJWTVerifier(config).Verify(token)
+    if err != nil {
+        t.Fatalf("Expected issued token to verify, got %v", err)
+    }
+    if principal.ID != "user-1" || !principal.HasRole(auth.RoleSelf) {
+        t.Errorf("Unexpected principal %+v", principal)
+    }
+}
+
+func TestAuthenticator_MissingCredentials(t *testing.T) {
+    authenticator := NewAuthenticator(&APIKeyVerifier{}, NewJWTVerifier(JWTConfig{HMACSecret: []byte("x")}))
+    
+    _, err := authenticator.Authenticate(httptest.NewRequest("GET", "/users", nil))
+    if !errors.Is(err, auth.ErrUnauthenticated) {
+        t.Errorf("Expected ErrUnauthenticated, got %v", err)
+    }
+}
+
+func signHS256(t *testing.T, secret []byte, claims Claims) string {
+    t.Helper()
+    signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return signed
+}
@@ -0,0 +1,56 @@
+package authn
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// TokenIssuer assina access tokens HS256 com o mesmo segredo, issuer e
+// audience que o JWTVerifier aceita.
+type TokenIssuer struct {
+    secret   []byte
+    issuer   string
+    audience string
+    ttl      time.Duration
+}
+
+func NewTokenIssuer(config JWTConfig, ttl time.Duration) (*TokenIssuer, error) {
+    if len(config.HMACSecret) == 0 {
+        return nil, errors.New("an HMAC secret is required to issue tokens")
+    }
+    return &TokenIssuer{
+        secret:   config.HMACSecret,
+        issuer:   config.Issuer,
+        audience: config.Audience,
+        ttl:      ttl,
+    }, nil
+}
+
+func (i *TokenIssuer) Issue(subject string, roles []string) (string, time.Time, error) {
+    now := time.Now()
+    expiresAt := now.Add(i.ttl)
+    
+    claims := Claims{
+        Roles: roles,
+        RegisteredClaims: jwt.RegisteredClaims{
+            ID:        uuid.New().String(),
+            Subject:   subject,
+            Issuer:    i.issuer,
+            IssuedAt:  jwt.NewNumericDate(now),
+            ExpiresAt: jwt.NewNumericDate(expiresAt),
+        },
+    }
+    if i.audience != "" {
+        claims.Audience = jwt.ClaimStrings{i.audience}
+    }
+    
+    token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+    if err != nil {
+        return "", time.Time{}, fmt.Errorf("sign access token: %w", err)
+    }
+    return token, expiresAt, nil
+}
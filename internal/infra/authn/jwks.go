@@ -0,0 +1,138 @@
+package authn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+type jsonWebKey struct {
+    Kty string `json:"kty"`
+    Kid string `json:"kid"`
+    Alg string `json:"alg"`
+    Use string `json:"use"`
+    N   string `json:"n"`
+    E   string `json:"e"`
+    Crv string `json:"crv"`
+    X   string `json:"x"`
+    Y   string `json:"y"`
+}
+
+// JWKS guarda as chaves públicas de um arquivo JWKS local, indexadas pelo kid.
+type JWKS struct {
+    keys       map[string]any
+    algorithms []string
+}
+
+func LoadJWKSFile(path string) (*JWKS, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("read jwks file: %w", err)
+    }
+    return ParseJWKS(data)
+}
+
+func ParseJWKS(data []byte) (*JWKS, error) {
+    var set struct {
+        Keys []jsonWebKey `json:"keys"`
+    }
+    if err := json.Unmarshal(data, &set); err != nil {
+        return nil, fmt.Errorf("parse jwks: %w", err)
+    }
+    
+    jwks := &JWKS{keys: make(map[string]any)}
+    seen := make(map[string]bool)
+    for _, k := range set.Keys {
+        if k.Use != "" && k.Use != "sig" {
+            continue
+        }
+        
+        key, alg, err := k.publicKey()
+        if err != nil {
+            return nil, fmt.Errorf("jwks key %q: %w", k.Kid, err)
+        }
+        jwks.keys[k.Kid] = key
+        if !seen[alg] {
+            seen[alg] = true
+            jwks.algorithms = append(jwks.algorithms, alg)
+        }
+    }
+    
+    if len(jwks.keys) == 0 {
+        return nil, fmt.Errorf("jwks has no signing keys")
+    }
+    return jwks, nil
+}
+
+func (j *JWKS) Algorithms() []string {
+    return j.algorithms
+}
+
+// Key devolve a chave do kid. Sem kid, só é aceito se houver uma única chave.
+func (j *JWKS) Key(kid string) (any, error) {
+    if kid == "" && len(j.keys) == 1 {
+        for _, key := range j.keys {
+            return key, nil
+        }
+    }
+    
+    key, ok := j.keys[kid]
+    if !ok {
+        return nil, fmt.Errorf("unknown key id %q", kid)
+    }
+    return key, nil
+}
+
+func (k jsonWebKey) publicKey() (any, string, error) {
+    switch k.Kty {
+    case "RSA":
+        n, err := decodeBigInt(k.N)
+        if err != nil {
+            return nil, "", err
+        }
+        e, err := decodeBigInt(k.E)
+        if err != nil {
+            return nil, "", err
+        }
+        alg := k.Alg
+        if alg == "" {
+            alg = "RS256"
+        }
+        return &rsa.PublicKey{N: n, E: int(e.Int64())}, alg, nil
+    case "EC":
+        var curve elliptic.Curve
+        alg := k.Alg
+        switch k.Crv {
+        case "P-256":
+            curve, alg = elliptic.P256(), "ES256"
+        case "P-384":
+            curve, alg = elliptic.P384(), "ES384"
+        default:
+            return nil, "", fmt.Errorf("unsupported curve %q", k.Crv)
+        }
+        x, err := decodeBigInt(k.X)
+        if err != nil {
+            return nil, "", err
+        }
+        y, err := decodeBigInt(k.Y)
+        if err != nil {
+            return nil, "", err
+        }
+        return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, alg, nil
+    default:
+        return nil, "", fmt.Errorf("unsupported key type %q", k.Kty)
+    }
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+    data, err := base64.RawURLEncoding.DecodeString(s)
+    if err != nil {
+        return nil, fmt.Errorf("invalid base64url value: %w", err)
+    }
+    return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,84 @@
+package authn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Claims struct {
+    Roles []string `json:"roles,omitempty"`
+    jwt.RegisteredClaims
+}
+
+type JWTConfig struct {
+    // HMACSecret habilita tokens HS256
+    HMACSecret []byte
+    // JWKS habilita tokens RS256/ES256 assinados pelas chaves do arquivo
+    JWKS     *JWKS
+    Issuer   string
+    Audience string
+}
+
+type JWTVerifier struct {
+    config JWTConfig
+    parser *jwt.Parser
+}
+
+func NewJWTVerifier(config JWTConfig) *JWTVerifier {
+    var methods []string
+    if len(config.HMACSecret) > 0 {
+        methods = append(methods, jwt.SigningMethodHS256.Alg())
+    }
+    if config.JWKS != nil {
+        methods = append(methods, config.JWKS.Algorithms()...)
+    }
+    
+    opts := []jwt.ParserOption{
+        jwt.WithValidMethods(methods),
+        jwt.WithExpirationRequired(),
+    }
+    if config.Issuer != "" {
+        opts = append(opts, jwt.WithIssuer(config.Issuer))
+    }
+    if config.Audience != "" {
+        opts = append(opts, jwt.WithAudience(config.Audience))
+    }
+    
+    return &JWTVerifier{config: config, parser: jwt.NewParser(opts...)}
+}
+
+func (v *JWTVerifier) Enabled() bool {
+    return len(v.config.HMACSecret) > 0 || v.config.JWKS != nil
+}
+
+func (v *JWTVerifier) Verify(tokenString string) (*auth.Principal, error) {
+    var claims Claims
+    _, err := v.parser.ParseWithClaims(tokenString, &claims, v.keyFunc)
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", auth.ErrUnauthenticated, err)
+    }
+    if claims.Subject == "" {
+        return nil, fmt.Errorf("%w: token has no subject", auth.ErrUnauthenticated)
+    }
+    
+    return &auth.Principal{
+        ID:     claims.Subject,
+        Method: auth.MethodJWT,
+        Roles:  claims.Roles,
+    }, nil
+}
+
+func (v *JWTVerifier) keyFunc(token *jwt.Token) (any, error) {
+    if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+        return v.config.HMACSecret, nil
+    }
+    
+    if v.config.JWKS == nil {
+        return nil, errors.New("no public keys configured")
+    }
+    kid, _ := token.Header["kid"].(string)
+    return v.config.JWKS.Key(kid)
+}
@@ -0,0 +1,34 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Tipos das notificações de alteração de usuário enviadas aos clientes.
+const (
+    EventUserCreated = "user.created"
+    EventUserUpdated = "user.updated"
+    EventUserDeleted = "user.deleted"
+    // EventReset avisa que alterações podem ter se perdido (Last-Event-ID
+    // fora do buffer, reconexão do LISTEN): o cliente deve recarregar a lista
+    EventReset = "reset"
+)
+
+// Event é uma notificação. ID é opaco e gerado por quem publica, para que
+// todas as réplicas vejam o mesmo ID e o Last-Event-ID sirva em qualquer uma.
+type Event struct {
+    ID   string          `json:"id"`
+    Type string          `json:"type"`
+    Data json.RawMessage `json:"data"`
+}
+
+// Broadcaster distribui notificações para os clientes conectados.
+type Broadcaster interface {
+    // Publish envia o evento a todos os assinantes, deste processo ou, com
+    // Postgres, de todas as réplicas.
+    Publish(ctx context.Context, event Event) error
+    // Subscribe começa a receber eventos. Com lastEventID, os eventos
+    // seguintes a ele ainda no buffer são entregues primeiro.
+    Subscribe(lastEventID string) *Subscription
+}
@@ -0,0 +1,144 @@
+package broadcast
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+    DefaultBufferSize   = 1000
+    DefaultClientBuffer = 64
+)
+
+// Hub é o Broadcaster em memória. Guarda os últimos eventos num buffer
+// circular para a retomada por Last-Event-ID e entrega a cada assinante por
+// um canal próprio, sem nunca bloquear: o assinante que deixa o canal
+// encher é desconectado e retoma do buffer ao reconectar, sem atrasar os
+// demais.
+type Hub struct {
+    ring         []Event
+    // next é a posição do próximo evento no ring; size, quantos há
+    next         int
+    size         int
+    clientBuffer int
+    subscribers  map[*Subscription]struct{}
+    mutex        sync.Mutex
+}
+
+var _ Broadcaster = (*Hub)(nil)
+
+func NewHub(bufferSize, clientBuffer int) *Hub {
+    if bufferSize <= 0 {
+        bufferSize = DefaultBufferSize
+    }
+    if clientBuffer <= 0 {
+        clientBuffer = DefaultClientBuffer
+    }
+    return &Hub{
+        ring:         make([]Event, bufferSize),
+        clientBuffer: clientBuffer,
+        subscribers:  make(map[*Subscription]struct{}),
+    }
+}
+
+// Publish entrega direto aos assinantes deste processo.
+func (h *Hub) Publish(ctx context.Context, event Event) error {
+    h.Broadcast(event)
+    return nil
+}
+
+// Broadcast guarda o evento no buffer e o repassa a cada assinante.
+func (h *Hub) Broadcast(event Event) {
+    h.mutex.Lock()
+    defer h.mutex.Unlock()
+
+    h.ring[h.next] = event
+    h.next = (h.next + 1) % len(h.ring)
+    h.size = min(h.size+1, len(h.ring))
+
+    for sub := range h.subscribers {
+        select {
+        case sub.events <- event:
+        default:
+            h.drop(sub)
+        }
+    }
+}
+
+// Reset esvazia o buffer e manda EventReset a todos: usado quando eventos
+// podem ter se perdido e a retomada não é mais confiável.
+func (h *Hub) Reset(id string) {
+    h.mutex.Lock()
+    h.next, h.size = 0, 0
+    h.mutex.Unlock()
+
+    h.Broadcast(Event{ID: id, Type: EventReset, Data: []byte("{}")})
+}
+
+func (h *Hub) Subscribe(lastEventID string) *Subscription {
+    h.mutex.Lock()
+    defer h.mutex.Unlock()
+
+    sub := &Subscription{hub: h, events: make(chan Event, h.clientBuffer), done: make(chan struct{})}
+    if lastEventID != "" {
+        replay, found := h.since(lastEventID)
+        if !found {
+            replay = []Event{{ID: lastEventID, Type: EventReset, Data: []byte("{}")}}
+        }
+        sub.Replay = replay
+    }
+    h.subscribers[sub] = struct{}{}
+    return sub
+}
+
+// since devolve os eventos do buffer posteriores a id, do mais antigo para
+// o mais novo.
+func (h *Hub) since(id string) ([]Event, bool) {
+    start := (h.next - h.size + len(h.ring)) % len(h.ring)
+    for i := 0; i < h.size; i++ {
+        if h.ring[(start+i)%len(h.ring)].ID != id {
+            continue
+        }
+        replay := make([]Event, 0, h.size-i-1)
+        for j := i + 1; j < h.size; j++ {
+            replay = append(replay, h.ring[(start+j)%len(h.ring)])
+        }
+        return replay, true
+    }
+    return nil, false
+}
+
+// drop desconecta o assinante; precisa ser chamado com o mutex.
+func (h *Hub) drop(sub *Subscription) {
+    if _, ok := h.subscribers[sub]; ok {
+        delete(h.subscribers, sub)
+        close(sub.done)
+    }
+}
+
+// Subscription é um cliente conectado. Replay traz o que ele perdeu e deve
+// ser enviado antes de Events.
+type Subscription struct {
+    Replay []Event
+    hub    *Hub
+    events chan Event
+    done   chan struct{}
+}
+
+// Events entrega os eventos publicados depois do Subscribe.
+func (s *Subscription) Events() <-chan Event {
+    return s.events
+}
+
+// Done é fechado quando o hub desconecta o assinante por não acompanhar
+// o ritmo dos eventos, ou depois de Close.
+func (s *Subscription) Done() <-chan struct{} {
+    return s.done
+}
+
+func (s *Subscription) Close() {
+    s.hub.mutex.Lock()
+    defer s.hub.mutex.Unlock()
+
+    s.hub.drop(s)
+}
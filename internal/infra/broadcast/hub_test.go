@@ -0,0 +1,91 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func event(id string) Event {
+    return Event{ID: id, Type: EventUserUpdated, Data: []byte(`{"id":"` + id + `"}`)}
+}
+
+func eventIDs(events []Event) []string {
+    ids := make([]string, 0, len(events))
+    for _, e := range events {
+        ids = append(ids, e.ID)
+    }
+    return ids
+}
+
+func TestHub_Subscribe_ReplaysEventsAfterLastEventID(t *testing.T) {
+    // Arrange
+    hub := NewHub(10, 10)
+    for i := 1; i <= 4; i++ {
+        _ = hub.Publish(context.Background(), event(fmt.Sprint(i)))
+    }
+    
+    // Act
+    sub := hub.Subscribe("2")
+    defer sub.Close()
+    hub.Broadcast(event("5"))
+    
+    // Assert
+    if got := fmt.Sprint(eventIDs(sub.Replay)); got != "[3 4]" {
+        t.Errorf("Expected replay [3 4], got %s", got)
+    }
+    if live := <-sub.Events(); live.ID != "5" {
+        t.Errorf("Expected live event 5, got %s", live.ID)
+    }
+}
+
+func TestHub_Subscribe_ResetsWhenLastEventIDLeftTheBuffer(t *testing.T) {
+    hub := NewHub(2, 10)
+    for i := 1; i <= 3; i++ {
+        hub.Broadcast(event(fmt.Sprint(i)))
+    }
+    
+    sub := hub.Subscribe("1")
+    defer sub.Close()
+    
+    if len(sub.Replay) != 1 || sub.Replay[0].Type != EventReset {
+        t.Errorf("Expected a single reset event, got %+v", sub.Replay)
+    }
+}
+
+func TestHub_Broadcast_DropsSlowSubscriberWithoutBlockingOthers(t *testing.T) {
+    // Arrange
+    hub := NewHub(10, 2)
+    slow := hub.Subscribe("")
+    fast := hub.Subscribe("")
+    defer fast.Close()
+    
+    // Act
+    var received []Event
+    for i := 1; i <= 3; i++ {
+        hub.Broadcast(event(fmt.Sprint(i)))
+        received = append(received, <-fast.Events())
+    }
+    
+    // Assert
+    select {
+    case <-slow.Done():
+    default:
+        t.Error("Expected slow subscriber to be dropped")
+    }
+    select {
+    case <-fast.Done():
+        t.Error("Expected fast subscriber to stay connected")
+    default:
+    }
+    if got := fmt.Sprint(eventIDs(received)); got != "[1 2 3]" {
+        t.Errorf("Expected fast subscriber to get [1 2 3], got %s", got)
+    }
+    
+    // Ao reconectar, o lento retoma do buffer
+    resumed := hub.Subscribe("2")
+    defer resumed.Close()
+    if got := fmt.Sprint(eventIDs(resumed.Replay)); got != "[3]" {
+        t.Errorf("Expected resumed replay [3], got %s", got)
+    }
+}
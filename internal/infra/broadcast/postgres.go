@@ -0,0 +1,102 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresChannel é o canal do LISTEN/NOTIFY. O payload do NOTIFY tem
+// limite de 8000 bytes, folgado para as notificações de usuário.
+const PostgresChannel = "user_events"
+
+// reconnectDelay é a espera entre tentativas de refazer o LISTEN.
+const reconnectDelay = time.Second
+
+// PostgresBroadcaster publica com NOTIFY e entrega aos assinantes locais o
+// que chega pelo LISTEN, inclusive o que este processo publicou: assim
+// todas as réplicas veem os mesmos eventos, na mesma ordem.
+type PostgresBroadcaster struct {
+    hub    *Hub
+    pool   *pgxpool.Pool
+    logger *slog.Logger
+}
+
+var _ Broadcaster = (*PostgresBroadcaster)(nil)
+
+func NewPostgresBroadcaster(pool *pgxpool.Pool, hub *Hub, logger *slog.Logger) *PostgresBroadcaster {
+    return &PostgresBroadcaster{hub: hub, pool: pool, logger: logger}
+}
+
+func (b *PostgresBroadcaster) Publish(ctx context.Context, event Event) error {
+    payload, err := json.Marshal(event)
+    if err != nil {
+        return fmt.Errorf("encode %s notification: %w", event.Type, err)
+    }
+    if _, err := b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", PostgresChannel, string(payload)); err != nil {
+        return fmt.Errorf("notify %s: %w", event.Type, err)
+    }
+    return nil
+}
+
+func (b *PostgresBroadcaster) Subscribe(lastEventID string) *Subscription {
+    return b.hub.Subscribe(lastEventID)
+}
+
+// Run escuta o canal numa conexão dedicada até o contexto ser cancelado. Se
+// a conexão cair, refaz o LISTEN e manda EventReset: o que foi notificado
+// nesse intervalo não chegou e os clientes precisam recarregar.
+func (b *PostgresBroadcaster) Run(ctx context.Context) {
+    for reconnect := false; ; reconnect = true {
+        err := b.listen(ctx, reconnect)
+        if ctx.Err() != nil {
+            return
+        }
+        b.logger.ErrorContext(ctx, "user events listener disconnected", "error", err)
+
+        select {
+        case <-ctx.Done():
+            return
+        case <-time.After(reconnectDelay):
+        }
+    }
+}
+
+func (b *PostgresBroadcaster) listen(ctx context.Context, reconnect bool) error {
+    conn, err := b.pool.Acquire(ctx)
+    if err != nil {
+        return err
+    }
+    // Devolvida ao pool, a conexão continuaria no LISTEN; Hijack a tira de
+    // lá para ser fechada
+    defer func() {
+        _ = conn.Hijack().Close(context.WithoutCancel(ctx))
+    }()
+
+    if _, err := conn.Exec(ctx, "LISTEN "+PostgresChannel); err != nil {
+        return err
+    }
+    if reconnect {
+        b.hub.Reset(uuid.New().String())
+    }
+    b.logger.InfoContext(ctx, "listening for user events", "channel", PostgresChannel)
+
+    for {
+        notification, err := conn.Conn().WaitForNotification(ctx)
+        if err != nil {
+            return err
+        }
+
+        var event Event
+        if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+            b.logger.WarnContext(ctx, "discarding malformed user event notification", "error", err)
+            continue
+        }
+        b.hub.Broadcast(event)
+    }
+}
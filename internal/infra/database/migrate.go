@@ -0,0 +1,239 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey identifica o advisory lock usado pelo runner. Qualquer
+// número fixo serve, desde que todas as réplicas usem o mesmo.
+const migrationLockKey int64 = 7_316_052_201
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type Migration struct {
+    Version int64
+    Name    string
+    Up      string
+    Down    string
+}
+
+type MigrationStatus struct {
+    Migration
+    AppliedAt *time.Time
+}
+
+type Migrator struct {
+    pool       *pgxpool.Pool
+    migrations []Migration
+}
+
+func NewMigrator(pool *pgxpool.Pool) (*Migrator, error) {
+    migrations, err := loadMigrations(migrationFiles)
+    if err != nil {
+        return nil, err
+    }
+    return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// Up aplica, em ordem, todas as migrations pendentes.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+    var applied []Migration
+    
+    err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+        versions, err := appliedVersions(ctx, conn)
+        if err != nil {
+            return err
+        }
+        
+        for _, mig := range m.migrations {
+            if _, ok := versions[mig.Version]; ok {
+                continue
+            }
+            if err := runMigration(ctx, conn, mig, mig.Up, true); err != nil {
+                return err
+            }
+            applied = append(applied, mig)
+        }
+        return nil
+    })
+    
+    return applied, err
+}
+
+// Down reverte as últimas `steps` migrations aplicadas.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+    var reverted []Migration
+    
+    err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+        versions, err := appliedVersions(ctx, conn)
+        if err != nil {
+            return err
+        }
+        
+        for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+            mig := m.migrations[i]
+            if _, ok := versions[mig.Version]; !ok {
+                continue
+            }
+            if mig.Down == "" {
+                return fmt.Errorf("migration %d_%s has no down script", mig.Version, mig.Name)
+            }
+            if err := runMigration(ctx, conn, mig, mig.Down, false); err != nil {
+                return err
+            }
+            reverted = append(reverted, mig)
+        }
+        return nil
+    })
+    
+    return reverted, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+    var statuses []MigrationStatus
+    
+    err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+        versions, err := appliedVersions(ctx, conn)
+        if err != nil {
+            return err
+        }
+        
+        for _, mig := range m.migrations {
+            status := MigrationStatus{Migration: mig}
+            if appliedAt, ok := versions[mig.Version]; ok {
+                status.AppliedAt = &appliedAt
+            }
+            statuses = append(statuses, status)
+        }
+        return nil
+    })
+    
+    return statuses, err
+}
+
+// withLock executa fn numa conexão dedicada segurando o advisory lock, para
+// que réplicas subindo ao mesmo tempo não apliquem a mesma migration.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+    conn, err := m.pool.Acquire(ctx)
+    if err != nil {
+        return fmt.Errorf("acquire connection: %w", err)
+    }
+    defer conn.Release()
+    
+    if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+        return fmt.Errorf("acquire migration lock: %w", err)
+    }
+    defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey)
+    
+    _, err = conn.Exec(ctx, `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version BIGINT PRIMARY KEY,
+            name TEXT NOT NULL,
+            applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`)
+    if err != nil {
+        return fmt.Errorf("create schema_migrations: %w", err)
+    }
+    
+    return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+    rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+    if err != nil {
+        return nil, fmt.Errorf("read schema_migrations: %w", err)
+    }
+    defer rows.Close()
+    
+    versions := make(map[int64]time.Time)
+    for rows.Next() {
+        var version int64
+        var appliedAt time.Time
+        if err := rows.Scan(&version, &appliedAt); err != nil {
+            return nil, err
+        }
+        versions[version] = appliedAt
+    }
+    return versions, rows.Err()
+}
+
+func runMigration(ctx context.Context, conn *pgxpool.Conn, mig Migration, script string, up bool) error {
+    err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+        if _, err := tx.Exec(ctx, script); err != nil {
+            return err
+        }
+        
+        if up {
+            _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name)
+            return err
+        }
+        _, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+        return err
+    })
+    if err != nil {
+        return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+    }
+    return nil
+}
+
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+    entries, err := fs.ReadDir(fsys, "migrations")
+    if err != nil {
+        return nil, err
+    }
+    
+    byVersion := make(map[int64]*Migration)
+    for _, entry := range entries {
+        match := migrationFilePattern.FindStringSubmatch(entry.Name())
+        if match == nil {
+            return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+        }
+        
+        version, _ := strconv.ParseInt(match[1], 10, 64)
+        content, err := fs.ReadFile(fsys, "migrations/"+entry.Name())
+        if err != nil {
+            return nil, err
+        }
+        
+        mig, ok := byVersion[version]
+        if !ok {
+            mig = &Migration{Version: version, Name: match[2]}
+            byVersion[version] = mig
+        }
+        if mig.Name != match[2] {
+            return nil, fmt.Errorf("migration %d has conflicting names: %s and %s", version, mig.Name, match[2])
+        }
+        
+        if match[3] == "up" {
+            mig.Up = string(content)
+        } else {
+            mig.Down = string(content)
+        }
+    }
+    
+    migrations := make([]Migration, 0, len(byVersion))
+    for _, mig := range byVersion {
+        if mig.Up == "" {
+            return nil, fmt.Errorf("migration %d_%s has no up script", mig.Version, mig.Name)
+        }
+        migrations = append(migrations, *mig)
+    }
+    sort.Slice(migrations, func(i, j int) bool {
+        return migrations[i].Version < migrations[j].Version
+    })
+    
+    return migrations, nil
+}
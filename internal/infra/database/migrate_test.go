@@ -0,0 +1,55 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations_SortsAndPairsScripts(t *testing.T) {
+    // Arrange
+    fsys := fstest.MapFS{
+        "migrations/0002_add_index.up.sql":      {Data: []byte("CREATE INDEX ...")},
+        "migrations/0001_create_users.up.sql":   {Data: []byte("CREATE TABLE ...")},
+        "migrations/0001_create_users.down.sql": {Data: []byte("DROP TABLE ...")},
+    }
+    
+    // Act
+    migrations, err := loadMigrations(fsys)
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if len(migrations) != 2 {
+        t.Fatalf("Expected 2 migrations, got %d", len(migrations))
+    }
+    if migrations[0].Version != 1 || migrations[1].Version != 2 {
+        t.Errorf("Expected versions [1 2], got [%d %d]", migrations[0].Version, migrations[1].Version)
+    }
+    if migrations[0].Down != "DROP TABLE ..." {
+        t.Errorf("Expected down script to be paired with version 1, got %q", migrations[0].Down)
+    }
+}
+
+func TestLoadMigrations_RejectsInvalidNames(t *testing.T) {
+    fsys := fstest.MapFS{
+        "migrations/create_users.sql": {Data: []byte("CREATE TABLE ...")},
+    }
+    
+    if _, err := loadMigrations(fsys); err == nil {
+        t.Error("Expected error for file without version prefix")
+    }
+}
+
+func TestEmbeddedMigrations_AreValid(t *testing.T) {
+    migrations, err := loadMigrations(migrationFiles)
+    if err != nil {
+        t.Fatalf("Expected embedded migrations to load, got %v", err)
+    }
+    
+    for _, mig := range migrations {
+        if mig.Down == "" {
+            t.Errorf("Migration %d_%s has no down script", mig.Version, mig.Name)
+        }
+    }
+}
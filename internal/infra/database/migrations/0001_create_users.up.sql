@@ -1,7 +1,6 @@
--- Criar extensões necessárias
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
--- Criar tabela de usuários
+-- IF NOT EXISTS mantém compatibilidade com bancos criados pelo antigo scripts/init.sql
 CREATE TABLE IF NOT EXISTS users (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     name VARCHAR(255) NOT NULL,
@@ -10,12 +9,5 @@ CREATE TABLE IF NOT EXISTS users (
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
--- Criar índices para performance
 CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
-CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
-
--- Inserir dados de exemplo (opcional)
-INSERT INTO users (name, email) VALUES 
-    ('João Silva', 'joao@example.com'),
-    ('Maria Santos', 'maria@example.com')
-ON CONFLICT (email) DO NOTHING;
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users(created_at, id);
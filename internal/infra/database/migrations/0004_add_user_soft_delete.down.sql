@@ -0,0 +1,9 @@
+-- Excluídos logicamente não sobrevivem ao rollback: sem deleted_at eles
+-- voltariam a aparecer como ativos e poderiam violar a unicidade do email
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS users_email_active_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- O email só precisa ser único entre usuários ativos: um excluído não
+-- bloqueia o cadastro de outro com o mesmo endereço
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX users_email_active_key ON users(email) WHERE deleted_at IS NULL;
+
+-- Usado pelo job de expurgo
+CREATE INDEX idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Versão para controle de concorrência otimista (ETag / If-Match)
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS users_email_normalized_active_key;
+CREATE UNIQUE INDEX users_email_active_key ON users(email) WHERE deleted_at IS NULL;
+ALTER TABLE users DROP COLUMN IF EXISTS email_normalized;
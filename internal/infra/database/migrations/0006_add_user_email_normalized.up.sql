@@ -0,0 +1,11 @@
+-- Forma canônica do email (entity.Email.Canonical), usada para a unicidade.
+-- Os registros existentes recebem só o email em minúsculas; a normalização
+-- de IDNA e de provedores vale para cadastros e alterações daqui em diante.
+-- Se houver dois usuários ativos com o mesmo email ignorando maiúsculas, a
+-- criação do índice falha e eles precisam ser resolvidos antes.
+ALTER TABLE users ADD COLUMN email_normalized VARCHAR(255);
+UPDATE users SET email_normalized = lower(email);
+ALTER TABLE users ALTER COLUMN email_normalized SET NOT NULL;
+
+DROP INDEX IF EXISTS users_email_active_key;
+CREATE UNIQUE INDEX users_email_normalized_active_key ON users(email_normalized) WHERE deleted_at IS NULL;
@@ -0,0 +1,18 @@
+-- Eventos de domínio gravados na mesma transação da alteração e entregues
+-- depois pelo relay. Mensagens com status 'dead' esgotaram as tentativas.
+CREATE TABLE outbox (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Usado pelo relay para achar as mensagens a entregar
+CREATE INDEX idx_outbox_pending ON outbox(next_attempt_at) WHERE status = 'pending';
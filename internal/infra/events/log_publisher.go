@@ -0,0 +1,57 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// LogPublisher só registra o evento no log. Serve para desenvolvimento local.
+type LogPublisher struct {
+    logger *slog.Logger
+}
+
+func NewLogPublisher(logger *slog.Logger) *LogPublisher {
+    return &LogPublisher{logger: logger}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, msg Message) error {
+    p.logger.InfoContext(ctx, "event published",
+        "event_id", msg.ID, "event_type", msg.Type, "aggregate_id", msg.AggregateID, "data", string(msg.Data))
+    return nil
+}
+
+// FilePublisher anexa cada evento ao arquivo informado, um JSON por linha.
+// Útil em testes de ponta a ponta que precisam conferir os eventos.
+type FilePublisher struct {
+    path  string
+    mutex sync.Mutex
+}
+
+func NewFilePublisher(path string) *FilePublisher {
+    return &FilePublisher{path: path}
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, msg Message) error {
+    data, err := json.Marshal(msg)
+    if err != nil {
+        return fmt.Errorf("encode event: %w", err)
+    }
+    
+    p.mutex.Lock()
+    defer p.mutex.Unlock()
+    
+    f, err := os.OpenFile(p.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+    if err != nil {
+        return fmt.Errorf("open events file: %w", err)
+    }
+    defer f.Close()
+    
+    if _, err := f.Write(append(data, '\n')); err != nil {
+        return fmt.Errorf("write events file: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Message é o envelope entregue aos consumidores. ID é estável entre
+// tentativas: a entrega é pelo menos uma vez e o consumidor deve descartar
+// IDs repetidos.
+type Message struct {
+    ID          string          `json:"id"`
+    Type        string          `json:"type"`
+    AggregateID string          `json:"aggregate_id"`
+    OccurredAt  time.Time       `json:"occurred_at"`
+    Data        json.RawMessage `json:"data"`
+}
+
+// Publisher entrega os eventos do outbox para fora da aplicação. Um erro
+// faz o relay tentar de novo mais tarde.
+type Publisher interface {
+    Publish(ctx context.Context, msg Message) error
+}
+
+// Fanout entrega cada mensagem a todos os publishers. Se algum falhar, o
+// relay repete a mensagem para todos: cada um deve tolerar repetições.
+type Fanout []Publisher
+
+func (f Fanout) Publish(ctx context.Context, msg Message) error {
+    var errs []error
+    for _, p := range f {
+        if err := p.Publish(ctx, msg); err != nil {
+            errs = append(errs, err)
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// Driver seleciona a implementação em NewPublisher.
+type Driver string
+
+const (
+    DriverLog  Driver = "log"
+    DriverFile Driver = "file"
+)
+
+type Config struct {
+    Driver   Driver
+    FilePath string
+}
+
+func NewPublisher(config Config, logger *slog.Logger) (Publisher, error) {
+    switch config.Driver {
+    case DriverLog, "":
+        return NewLogPublisher(logger), nil
+    case DriverFile:
+        return NewFilePublisher(config.FilePath), nil
+    default:
+        return nil, fmt.Errorf("unknown event publisher driver %q (expected log or file)", config.Driver)
+    }
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilePublisher_AppendsJSONLines(t *testing.T) {
+    // Arrange
+    path := filepath.Join(t.TempDir(), "events.log")
+    publisher := NewFilePublisher(path)
+    ctx := context.Background()
+    
+    // Act
+    for _, id := range []string{"1", "2"} {
+        err := publisher.Publish(ctx, Message{
+            ID:          id,
+            Type:        "user.created",
+            AggregateID: "user-" + id,
+            OccurredAt:  time.Date(2024, 7, 8, 10, 30, 0, 0, time.UTC),
+            Data:        json.RawMessage(`{"user_id":"user-` + id + `"}`),
+        })
+        if err != nil {
+            t.Fatalf("Expected no error, got %v", err)
+        }
+    }
+    
+    // Assert
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Expected events file, got %v", err)
+    }
+    lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+    if len(lines) != 2 {
+        t.Fatalf("Expected 2 lines, got %d:\n%s", len(lines), data)
+    }
+    var msg Message
+    if err := json.Unmarshal([]byte(lines[1]), &msg); err != nil {
+        t.Fatalf("Expected JSON line, got %v", err)
+    }
+    if msg.ID != "2" || msg.Type != "user.created" || string(msg.Data) != `{"user_id":"user-2"}` {
+        t.Errorf("Unexpected message %+v", msg)
+    }
+}
+
+func TestNewPublisher_RejectsUnknownDriver(t *testing.T) {
+    if _, err := NewPublisher(Config{Driver: "carrier-pigeon"}, nil); err == nil {
+        t.Error("Expected error for unknown driver")
+    }
+}
@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+    StatusUp   Status = "up"
+    StatusDown Status = "down"
+)
+
+// CheckFunc verifica uma dependência. Os detalhes retornados entram no
+// relatório mesmo quando a verificação falha.
+type CheckFunc func(ctx context.Context) (map[string]any, error)
+
+type CheckResult struct {
+    Status    Status         `json:"status"`
+    LatencyMs float64        `json:"latency_ms"`
+    Error     string         `json:"error,omitempty"`
+    Details   map[string]any `json:"details,omitempty"`
+}
+
+type Report struct {
+    Status Status                 `json:"status"`
+    Checks map[string]CheckResult `json:"checks"`
+}
+
+type Registry struct {
+    checks  map[string]CheckFunc
+    timeout time.Duration
+    mutex   sync.RWMutex
+}
+
+func NewRegistry(timeout time.Duration) *Registry {
+    return &Registry{
+        checks:  make(map[string]CheckFunc),
+        timeout: timeout,
+    }
+}
+
+func (r *Registry) Register(name string, check CheckFunc) {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    r.checks[name] = check
+}
+
+// Run executa todas as verificações em paralelo, cada uma limitada pelo
+// timeout do registro. O relatório só fica "up" se todas passarem.
+func (r *Registry) Run(ctx context.Context) Report {
+    r.mutex.RLock()
+    checks := make(map[string]CheckFunc, len(r.checks))
+    for name, check := range r.checks {
+        checks[name] = check
+    }
+    r.mutex.RUnlock()
+    
+    report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(checks))}
+    
+    var (
+        wg    sync.WaitGroup
+        mutex sync.Mutex
+    )
+    for name, check := range checks {
+        wg.Add(1)
+        go func(name string, check CheckFunc) {
+            defer wg.Done()
+            
+            result := r.runCheck(ctx, check)
+            
+            mutex.Lock()
+            defer mutex.Unlock()
+            report.Checks[name] = result
+            if result.Status == StatusDown {
+                report.Status = StatusDown
+            }
+        }(name, check)
+    }
+    wg.Wait()
+    
+    return report
+}
+
+func (r *Registry) runCheck(ctx context.Context, check CheckFunc) CheckResult {
+    if r.timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, r.timeout)
+        defer cancel()
+    }
+    
+    start := time.Now()
+    details, err := check(ctx)
+    result := CheckResult{
+        Status:    StatusUp,
+        LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+        Details:   details,
+    }
+    if err != nil {
+        result.Status = StatusDown
+        result.Error = err.Error()
+    }
+    return result
+}
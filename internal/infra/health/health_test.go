@@ -0,0 +1,50 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Run_ReportsEachCheck(t *testing.T) {
+    // Arrange
+    registry := NewRegistry(time.Second)
+    registry.Register("ok", func(ctx context.Context) (map[string]any, error) {
+        return map[string]any{"conns": 3}, nil
+    })
+    registry.Register("broken", func(ctx context.Context) (map[string]any, error) {
+        return nil, errors.New("connection refused")
+    })
+    
+    // Act
+    report := registry.Run(context.Background())
+    
+    // Assert
+    if report.Status != StatusDown {
+        t.Errorf("Expected overall status down, got %s", report.Status)
+    }
+    if report.Checks["ok"].Status != StatusUp {
+        t.Errorf("Expected 'ok' check to be up, got %s", report.Checks["ok"].Status)
+    }
+    if report.Checks["broken"].Error != "connection refused" {
+        t.Errorf("Expected error message to be reported, got %q", report.Checks["broken"].Error)
+    }
+}
+
+func TestRegistry_Run_AppliesTimeout(t *testing.T) {
+    // Arrange
+    registry := NewRegistry(10 * time.Millisecond)
+    registry.Register("slow", func(ctx context.Context) (map[string]any, error) {
+        <-ctx.Done()
+        return nil, ctx.Err()
+    })
+    
+    // Act
+    report := registry.Run(context.Background())
+    
+    // Assert
+    if report.Checks["slow"].Status != StatusDown {
+        t.Error("Expected slow check to fail after timeout")
+    }
+}
@@ -0,0 +1,22 @@
+package health
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresCheck faz ping no pool e reporta suas estatísticas de conexões.
+func PostgresCheck(pool *pgxpool.Pool) CheckFunc {
+    return func(ctx context.Context) (map[string]any, error) {
+        stat := pool.Stat()
+        details := map[string]any{
+            "acquired_conns": stat.AcquiredConns(),
+            "idle_conns":     stat.IdleConns(),
+            "total_conns":    stat.TotalConns(),
+            "max_conns":      stat.MaxConns(),
+        }
+        
+        return details, pool.Ping(ctx)
+    }
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/service"
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+    authService *service.AuthService
+}
+
+func NewAuthHandler(authService *service.AuthService) *AuthHandler {
+    return &AuthHandler{
+        authService: authService,
+    }
+}
+
+// RegisterRoutes registra as rotas públicas de sessão; elas não passam pelo
+// middleware de autenticação.
+func (h *AuthHandler) RegisterRoutes(router gin.IRouter) {
+    authGroup := router.Group("/auth")
+    {
+        authGroup.POST("/login", h.Login)
+        authGroup.POST("/refresh", h.Refresh)
+        authGroup.POST("/logout", h.Logout)
+    }
+}
+
+// Login godoc
+// @Summary      Login
+// @Description  Troca email e senha por um access token JWT e um refresh token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials  body      dto.LoginRequest  true  "Email e senha"
+// @Success      200          {object}  dto.TokenResponse
+// @Failure      400          {object}  dto.ProblemDetails
+// @Failure      401          {object}  dto.ProblemDetails
+// @Failure      500          {object}  dto.ProblemDetails
+// @Router       /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+    var req dto.LoginRequest
+
+    if err := c.ShouldBindJSON(&req); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+
+    tokens, err := h.authService.Login(c.Request.Context(), req)
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+
+    c.Header("Cache-Control", "no-store")
+    c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh godoc
+// @Summary      Renovar tokens
+// @Description  Troca um refresh token válido por um novo par de tokens. O refresh token usado é revogado; reutilizá-lo revoga todas as sessões do usuário
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token  body      dto.RefreshTokenRequest  true  "Refresh token"
+// @Success      200    {object}  dto.TokenResponse
+// @Failure      400    {object}  dto.ProblemDetails
+// @Failure      401    {object}  dto.ProblemDetails
+// @Failure      500    {object}  dto.ProblemDetails
+// @Router       /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+    var req dto.RefreshTokenRequest
+
+    if err := c.ShouldBindJSON(&req); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+
+    tokens, err := h.authService.Refresh(c.Request.Context(), req)
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+
+    c.Header("Cache-Control", "no-store")
+    c.JSON(http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary      Logout
+// @Description  Revoga o refresh token informado. O access token continua válido até expirar
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token  body      dto.RefreshTokenRequest  true  "Refresh token"
+// @Success      204
+// @Failure      400    {object}  dto.ProblemDetails
+// @Failure      500    {object}  dto.ProblemDetails
+// @Router       /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+    var req dto.RefreshTokenRequest
+
+    if err := c.ShouldBindJSON(&req); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+
+    if err := h.authService.Logout(c.Request.Context(), req); err != nil {
+        _ = c.Error(err)
+        return
+    }
+
+    c.Status(http.StatusNoContent)
+}
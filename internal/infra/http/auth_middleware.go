@@ -0,0 +1,51 @@
+package http
+
+import (
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/authn"
+	"github.com/gin-gonic/gin"
+)
+
+// ContextPrincipalKey guarda o *auth.Principal autenticado no gin.Context.
+const ContextPrincipalKey = "principal"
+
+// Authenticate exige credenciais válidas (API key ou JWT) e publica o
+// principal no gin.Context e no context.Context da requisição.
+func Authenticate(authenticator *authn.Authenticator) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        principal, err := authenticator.Authenticate(c.Request)
+        if err != nil {
+            c.Header("WWW-Authenticate", `Bearer realm="user-api"`)
+            _ = c.Error(err)
+            c.Abort()
+            return
+        }
+        
+        setPrincipal(c, principal)
+        c.Next()
+    }
+}
+
+// AllowAnonymous é usado quando a autenticação está desligada por
+// configuração: toda requisição recebe o principal informado.
+func AllowAnonymous(principal *auth.Principal) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        setPrincipal(c, principal)
+        c.Next()
+    }
+}
+
+func PrincipalFromGin(c *gin.Context) (*auth.Principal, bool) {
+    value, ok := c.Get(ContextPrincipalKey)
+    if !ok {
+        return nil, false
+    }
+    principal, ok := value.(*auth.Principal)
+    return principal, ok
+}
+
+func setPrincipal(c *gin.Context, principal *auth.Principal) {
+    c.Set(ContextPrincipalKey, principal)
+    c.Set(ContextUserIDKey, principal.ID)
+    c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/service"
+	"github.com/gin-gonic/gin"
+)
+
+type EmailVerificationHandler struct {
+    verificationService *service.EmailVerificationService
+}
+
+func NewEmailVerificationHandler(verificationService *service.EmailVerificationService) *EmailVerificationHandler {
+    return &EmailVerificationHandler{
+        verificationService: verificationService,
+    }
+}
+
+// RegisterRoutes registra GET /verify, que é público: o token do link é a
+// própria credencial.
+func (h *EmailVerificationHandler) RegisterRoutes(router gin.IRouter) {
+    router.GET("/verify", h.VerifyEmail)
+}
+
+// VerifyEmail godoc
+// @Summary      Confirmar email
+// @Description  Consome o token enviado por email e marca o email do usuário como verificado. Cada token vale uma única vez
+// @Tags         users
+// @Produce      json
+// @Param        token  query     string  true  "Token recebido no email"
+// @Success      200    {object}  dto.UserResponse
+// @Failure      400    {object}  dto.ProblemDetails
+// @Failure      409    {object}  dto.ProblemDetails
+// @Failure      500    {object}  dto.ProblemDetails
+// @Router       /verify [get]
+func (h *EmailVerificationHandler) VerifyEmail(c *gin.Context) {
+    var query dto.VerifyEmailQuery
+    
+    if err := c.ShouldBindQuery(&query); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+    
+    user, err := h.verificationService.Verify(c.Request.Context(), query.Token)
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    c.JSON(http.StatusOK, user)
+}
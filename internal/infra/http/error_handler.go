@@ -0,0 +1,204 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/i18n"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/jsonpatch"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/logging"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/ratelimit"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+    ProblemContentType = "application/problem+json"
+    // problemTypeBase prefixa o código estável de cada problema no campo type
+    problemTypeBase = "/problems/"
+)
+
+var errRouteNotFound = errors.New("route not found")
+
+// problem é o erro já classificado, antes da tradução. code é estável e
+// independe do idioma; os textos saem do catálogo.
+type problem struct {
+    status     int
+    code       string
+    detailKey  string
+    reason     string
+    violations []fieldViolation
+}
+
+// fieldViolation é um campo inválido. param entra no {param} da mensagem;
+// com paramKey, ele próprio é uma chave do catálogo.
+type fieldViolation struct {
+    field      string
+    code       string
+    messageKey string
+    param      string
+    paramKey   bool
+}
+
+// ErrorHandler traduz os erros registrados pelos handlers com c.Error
+// em respostas HTTP. É o único lugar que conhece o mapeamento erro -> status.
+// Toda resposta de erro sai como application/problem+json (RFC 7807), no
+// idioma negociado pelo Accept-Language.
+func ErrorHandler(catalog *i18n.Catalog) gin.HandlerFunc {
+    registerFieldNames()
+    
+    return func(c *gin.Context) {
+        c.Next()
+        
+        if len(c.Errors) == 0 || c.Writer.Written() {
+            return
+        }
+        
+        err := c.Errors.Last()
+        var limitErr *ratelimit.LimitError
+        if errors.As(err.Err, &limitErr) {
+            c.Header("Retry-After", strconv.Itoa(int(math.Ceil(limitErr.RetryAfter.Seconds()))))
+        }
+        if errors.Is(err.Err, jsonpatch.ErrUnsupportedMediaType) {
+            c.Header("Accept-Patch", jsonpatch.AcceptPatch)
+        }
+        
+        writeProblem(c, catalog, mapError(err))
+    }
+}
+
+// Recovery substitui o gin.Recovery para que um panic também vire
+// problem+json. O stack continua indo para o log de erros do gin.
+func Recovery(catalog *i18n.Catalog) gin.HandlerFunc {
+    return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+        _ = c.Error(fmt.Errorf("panic: %v", recovered))
+        writeProblem(c, catalog, problem{status: http.StatusInternalServerError, code: "internal-server-error"})
+        c.Abort()
+    })
+}
+
+// RouteNotFound é o handler de router.NoRoute.
+func RouteNotFound() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        _ = c.Error(errRouteNotFound)
+    }
+}
+
+func writeProblem(c *gin.Context, catalog *i18n.Catalog, p problem) {
+    localizer := catalog.Negotiate(c.GetHeader("Accept-Language"))
+    
+    response := localize(localizer, p)
+    response.Instance = c.Request.URL.Path
+    response.TraceID = traceID(c)
+    
+    c.Header("Content-Language", localizer.Language())
+    c.Header("Vary", "Accept-Language")
+    c.Header("Content-Type", ProblemContentType)
+    c.JSON(response.Status, response)
+}
+
+func localize(l i18n.Localizer, p problem) dto.ProblemDetails {
+    detailKey := p.detailKey
+    if detailKey == "" {
+        detailKey = "problem." + p.code + ".detail"
+    }
+    
+    response := dto.ProblemDetails{
+        Type:   problemTypeBase + p.code,
+        Title:  l.Message("problem." + p.code + ".title"),
+        Status: p.status,
+        Detail: l.Message(detailKey, "reason", p.reason),
+    }
+    for _, v := range p.violations {
+        param := v.param
+        if v.paramKey {
+            param = l.Message(param)
+        }
+        response.Errors = append(response.Errors, dto.FieldError{
+            Field:   v.field,
+            Code:    v.code,
+            Message: l.Message(v.messageKey, "field", fieldLabel(l, v.field), "param", param),
+        })
+    }
+    return response
+}
+
+// fieldLabel traduz o nome do campo quando o catálogo conhece; senão usa o
+// nome como o cliente o enviou.
+func fieldLabel(l i18n.Localizer, field string) string {
+    if key := "label." + field; l.Has(key) {
+        return l.Message(key)
+    }
+    return field
+}
+
+// traceID usa o trace do OpenTelemetry; sem trace ativo, cai no request ID.
+func traceID(c *gin.Context) string {
+    if sc := trace.SpanContextFromContext(c.Request.Context()); sc.HasTraceID() {
+        return sc.TraceID().String()
+    }
+    return logging.RequestIDFromContext(c.Request.Context())
+}
+
+func mapError(err *gin.Error) problem {
+    if err.IsType(gin.ErrorTypeBind) {
+        detailKey, violations := bindingErrors(err.Err)
+        return problem{status: http.StatusBadRequest, code: "invalid-request", detailKey: detailKey, violations: violations}
+    }
+    
+    var validationErr *entity.ValidationError
+    switch {
+    case errors.As(err.Err, &validationErr):
+        p := problem{status: http.StatusBadRequest, code: "validation-failed"}
+        for _, f := range validationErr.Fields {
+            p.violations = append(p.violations, fieldViolation{field: f.Field, code: f.Code, messageKey: "field." + f.Code})
+        }
+        return p
+    case errors.Is(err.Err, errRouteNotFound):
+        return problem{status: http.StatusNotFound, code: "not-found"}
+    case errors.Is(err.Err, auth.ErrUnauthenticated):
+        return problem{status: http.StatusUnauthorized, code: "unauthorized"}
+    case errors.Is(err.Err, auth.ErrInvalidCredentials):
+        return problem{status: http.StatusUnauthorized, code: "invalid-credentials"}
+    case errors.Is(err.Err, auth.ErrInvalidRefreshToken):
+        return problem{status: http.StatusUnauthorized, code: "invalid-refresh-token"}
+    case errors.Is(err.Err, auth.ErrForbidden):
+        return problem{status: http.StatusForbidden, code: "forbidden"}
+    case errors.Is(err.Err, entity.ErrUserNotFound):
+        return problem{status: http.StatusNotFound, code: "user-not-found"}
+    case errors.Is(err.Err, entity.ErrWebhookNotFound):
+        return problem{status: http.StatusNotFound, code: "webhook-not-found"}
+    case errors.Is(err.Err, entity.ErrWebhookDeliveryNotFound):
+        return problem{status: http.StatusNotFound, code: "webhook-delivery-not-found"}
+    case errors.Is(err.Err, entity.ErrEmailTaken):
+        return problem{status: http.StatusConflict, code: "email-taken"}
+    case errors.Is(err.Err, entity.ErrUserNotDeleted):
+        return problem{status: http.StatusConflict, code: "user-not-deleted"}
+    case errors.Is(err.Err, entity.ErrEmailAlreadyVerified):
+        return problem{status: http.StatusConflict, code: "email-already-verified"}
+    case errors.Is(err.Err, entity.ErrPreconditionFailed):
+        return problem{status: http.StatusPreconditionFailed, code: "precondition-failed"}
+    case errors.Is(err.Err, entity.ErrVersionConflict):
+        return problem{status: http.StatusConflict, code: "version-conflict"}
+    case errors.Is(err.Err, jsonpatch.ErrUnsupportedMediaType):
+        return problem{status: http.StatusUnsupportedMediaType, code: "unsupported-media-type"}
+    case errors.Is(err.Err, jsonpatch.ErrInvalidPatch):
+        return problem{status: http.StatusBadRequest, code: "invalid-patch", reason: err.Error()}
+    case errors.Is(err.Err, jsonpatch.ErrPathNotFound):
+        return problem{status: http.StatusUnprocessableEntity, code: "unprocessable-patch", reason: err.Error()}
+    case errors.Is(err.Err, jsonpatch.ErrTestFailed):
+        return problem{status: http.StatusConflict, code: "patch-test-failed"}
+    case errors.Is(err.Err, entity.ErrInvalidToken):
+        return problem{status: http.StatusBadRequest, code: "invalid-token"}
+    case errors.Is(err.Err, ratelimit.ErrLimitExceeded):
+        return problem{status: http.StatusTooManyRequests, code: "too-many-requests"}
+    default:
+        return problem{status: http.StatusInternalServerError, code: "internal-server-error"}
+    }
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/i18n"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/jsonpatch"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/logging"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+func testCatalog(t *testing.T) *i18n.Catalog {
+    t.Helper()
+    catalog, err := i18n.NewCatalog("pt-BR")
+    if err != nil {
+        t.Fatalf("load catalog: %v", err)
+    }
+    return catalog
+}
+
+func TestErrorHandler_MapsDomainErrors(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    
+    cases := []struct {
+        name   string
+        err    error
+        status int
+    }{
+        {"not found", entity.ErrUserNotFound, http.StatusNotFound},
+        {"wrapped email taken", fmt.Errorf("update user: %w", entity.ErrEmailTaken), http.StatusConflict},
+        {"precondition failed", entity.ErrPreconditionFailed, http.StatusPreconditionFailed},
+        {"version conflict", &entity.VersionConflictError{ID: "u1", Version: 2}, http.StatusConflict},
+        {"unsupported patch", fmt.Errorf("%w: %q", jsonpatch.ErrUnsupportedMediaType, "text/plain"), http.StatusUnsupportedMediaType},
+        {"patch test failed", &jsonpatch.OperationError{Op: "test", Path: "/name", Err: jsonpatch.ErrTestFailed}, http.StatusConflict},
+        {"patch path not found", &jsonpatch.OperationError{Op: "replace", Path: "/nickname", Err: jsonpatch.ErrPathNotFound}, http.StatusUnprocessableEntity},
+        {"forbidden", &auth.ForbiddenError{PrincipalID: "u1", Action: auth.ActionDeleteUser}, http.StatusForbidden},
+        {"unauthenticated", auth.ErrUnauthenticated, http.StatusUnauthorized},
+        {"rate limited", &ratelimit.LimitError{RetryAfter: time.Minute}, http.StatusTooManyRequests},
+        {"validation", entity.NewValidationError("name", entity.CodeRequired, "name is required"), http.StatusBadRequest},
+        {"unknown", fmt.Errorf("connection refused"), http.StatusInternalServerError},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            // Arrange
+            router := gin.New()
+            router.Use(ErrorHandler(testCatalog(t)))
+            router.GET("/", func(c *gin.Context) {
+                _ = c.Error(tc.err)
+            })
+            
+            // Act
+            w := httptest.NewRecorder()
+            router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+            
+            // Assert
+            if w.Code != tc.status {
+                t.Errorf("Expected status %d, got %d", tc.status, w.Code)
+            }
+        })
+    }
+}
+
+func TestErrorHandler_WritesProblemDetails(t *testing.T) {
+    // Arrange
+    gin.SetMode(gin.TestMode)
+    router := gin.New()
+    router.Use(ErrorHandler(testCatalog(t)))
+    router.GET("/users/:id", func(c *gin.Context) {
+        _ = c.Error(entity.ErrUserNotFound)
+    })
+    req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+    req = req.WithContext(logging.WithRequestID(req.Context(), "req-1"))
+    
+    // Act
+    w := httptest.NewRecorder()
+    router.ServeHTTP(w, req)
+    
+    // Assert
+    if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, ProblemContentType) {
+        t.Errorf("Expected Content-Type %s, got %s", ProblemContentType, ct)
+    }
+    var problem dto.ProblemDetails
+    if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+        t.Fatalf("Expected a JSON body, got %v", err)
+    }
+    if problem.Type != "/problems/user-not-found" || problem.Status != http.StatusNotFound {
+        t.Errorf("Expected user-not-found problem with status 404, got %+v", problem)
+    }
+    if problem.Instance != "/users/42" {
+        t.Errorf("Expected instance /users/42, got %s", problem.Instance)
+    }
+    if problem.TraceID != "req-1" {
+        t.Errorf("Expected trace_id to fall back to the request ID, got %q", problem.TraceID)
+    }
+}
+
+func TestErrorHandler_TranslatesBindingErrors(t *testing.T) {
+    // Arrange
+    gin.SetMode(gin.TestMode)
+    router := gin.New()
+    router.Use(ErrorHandler(testCatalog(t)))
+    router.POST("/auth/login", func(c *gin.Context) {
+        var req dto.LoginRequest
+        if err := c.ShouldBindJSON(&req); err != nil {
+            _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        }
+    })
+    
+    // Act
+    w := httptest.NewRecorder()
+    router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{"email":"not-an-email"}`)))
+    
+    // Assert
+    if w.Code != http.StatusBadRequest {
+        t.Fatalf("Expected status 400, got %d", w.Code)
+    }
+    var problem dto.ProblemDetails
+    _ = json.Unmarshal(w.Body.Bytes(), &problem)
+    
+    got := map[string]string{}
+    for _, f := range problem.Errors {
+        got[f.Field] = f.Code
+    }
+    if got["password"] != "required" || got["email"] != "email" {
+        t.Errorf("Expected errors for password (required) and email (email), got %+v", problem.Errors)
+    }
+}
+
+func TestErrorHandler_LocalizesByAcceptLanguage(t *testing.T) {
+    // Arrange
+    gin.SetMode(gin.TestMode)
+    router := gin.New()
+    router.Use(ErrorHandler(testCatalog(t)))
+    router.POST("/users", func(c *gin.Context) {
+        var req dto.CreateUserRequest
+        if err := c.ShouldBindJSON(&req); err != nil {
+            _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        }
+    })
+    
+    cases := []struct {
+        acceptLanguage string
+        language       string
+        message        string
+    }{
+        {"es-AR,es;q=0.9", "es", "El campo nombre es obligatorio"},
+        {"en-US", "en", "name is required"},
+        {"fr-FR", "pt-BR", "O campo nome é obrigatório"},
+        {"", "pt-BR", "O campo nome é obrigatório"},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.acceptLanguage, func(t *testing.T) {
+            // Act
+            req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"email":"joao@email.com"}`))
+            req.Header.Set("Accept-Language", tc.acceptLanguage)
+            w := httptest.NewRecorder()
+            router.ServeHTTP(w, req)
+            
+            // Assert
+            if got := w.Header().Get("Content-Language"); got != tc.language {
+                t.Errorf("Expected Content-Language %s, got %s", tc.language, got)
+            }
+            var problem dto.ProblemDetails
+            _ = json.Unmarshal(w.Body.Bytes(), &problem)
+            if len(problem.Errors) != 1 {
+                t.Fatalf("Expected one field error, got %+v", problem.Errors)
+            }
+            if problem.Errors[0].Code != "required" || problem.Type != "/problems/invalid-request" {
+                t.Errorf("Expected stable codes regardless of language, got %+v", problem)
+            }
+            if problem.Errors[0].Message != tc.message {
+                t.Errorf("Expected message %q, got %q", tc.message, problem.Errors[0].Message)
+            }
+        })
+    }
+}
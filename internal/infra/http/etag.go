@@ -0,0 +1,38 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// setETag publica a versão do recurso como ETag forte: "<version>".
+func setETag(c *gin.Context, version int64) {
+    c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// parseIfMatch lê o header If-Match. "*" equivale a não mandar o header.
+// ETags fracas ou que não vieram de setETag nunca casam (RFC 9110 exige
+// comparação forte no If-Match), então um header só com elas sempre falha.
+func parseIfMatch(c *gin.Context) dto.Precondition {
+    header := strings.TrimSpace(c.GetHeader("If-Match"))
+    if header == "" || header == "*" {
+        return dto.Precondition{}
+    }
+    
+    precondition := dto.Precondition{Present: true}
+    for _, tag := range strings.Split(header, ",") {
+        tag = strings.TrimSpace(tag)
+        if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+            continue
+        }
+        version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+        if err != nil {
+            continue
+        }
+        precondition.IfMatch = append(precondition.IfMatch, version)
+    }
+    return precondition
+}
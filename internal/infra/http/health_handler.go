@@ -0,0 +1,74 @@
+package http
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/health"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+    registry *health.Registry
+    ready    atomic.Bool
+}
+
+func NewHealthHandler(registry *health.Registry) *HealthHandler {
+    return &HealthHandler{
+        registry: registry,
+    }
+}
+
+// SetReady controla a readiness da instância. No shutdown ela é desligada
+// antes de drenar as conexões, para o balanceador parar de enviar tráfego.
+func (h *HealthHandler) SetReady(ready bool) {
+    h.ready.Store(ready)
+}
+
+func (h *HealthHandler) RegisterRoutes(router *gin.Engine) {
+    router.GET("/livez", h.Liveness)
+    router.GET("/readyz", h.Readiness)
+    router.GET("/health", h.Liveness)
+}
+
+// Liveness godoc
+// @Summary      Liveness
+// @Description  Indica que o processo está de pé. Não verifica dependências
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  map[string]string
+// @Router       /livez [get]
+func (h *HealthHandler) Liveness(c *gin.Context) {
+    c.JSON(http.StatusOK, gin.H{
+        "status":  health.StatusUp,
+        "service": "user-api",
+    })
+}
+
+// Readiness godoc
+// @Summary      Readiness
+// @Description  Verifica as dependências registradas (banco de dados, etc.) e se a instância aceita tráfego
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  health.Report
+// @Failure      503  {object}  health.Report
+// @Router       /readyz [get]
+func (h *HealthHandler) Readiness(c *gin.Context) {
+    if !h.ready.Load() {
+        c.JSON(http.StatusServiceUnavailable, health.Report{
+            Status: health.StatusDown,
+            Checks: map[string]health.CheckResult{
+                "shutdown": {Status: health.StatusDown, Error: "instance is shutting down"},
+            },
+        })
+        return
+    }
+    
+    report := h.registry.Run(c.Request.Context())
+    if report.Status != health.StatusUp {
+        c.JSON(http.StatusServiceUnavailable, report)
+        return
+    }
+    
+    c.JSON(http.StatusOK, report)
+}
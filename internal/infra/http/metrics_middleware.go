@@ -0,0 +1,25 @@
+package http
+
+import (
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics registra contagem, latência e requisições em andamento. A rota é
+// o template do Gin (/users/:id), para não explodir a cardinalidade.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        start := time.Now()
+        m.HTTPRequestStarted()
+        
+        c.Next()
+        
+        route := c.FullPath()
+        if route == "" {
+            route = "unmatched"
+        }
+        m.HTTPRequestFinished(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+    }
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/service"
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordResetHandler struct {
+    resetService *service.PasswordResetService
+}
+
+func NewPasswordResetHandler(resetService *service.PasswordResetService) *PasswordResetHandler {
+    return &PasswordResetHandler{
+        resetService: resetService,
+    }
+}
+
+func (h *PasswordResetHandler) RegisterRoutes(router gin.IRouter) {
+    authGroup := router.Group("/auth")
+    {
+        authGroup.POST("/forgot-password", h.ForgotPassword)
+        authGroup.POST("/reset-password", h.ResetPassword)
+    }
+}
+
+// ForgotPassword godoc
+// @Summary      Esqueci minha senha
+// @Description  Envia um link de redefinição para o email, se ele estiver cadastrado. Responde 202 em ambos os casos. Limitado por email e por IP
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ForgotPasswordRequest  true  "Email da conta"
+// @Success      202
+// @Failure      400      {object}  dto.ProblemDetails
+// @Failure      429      {object}  dto.ProblemDetails
+// @Failure      500      {object}  dto.ProblemDetails
+// @Router       /auth/forgot-password [post]
+func (h *PasswordResetHandler) ForgotPassword(c *gin.Context) {
+    var req dto.ForgotPasswordRequest
+
+    if err := c.ShouldBindJSON(&req); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+
+    if err := h.resetService.RequestReset(c.Request.Context(), req, c.ClientIP()); err != nil {
+        _ = c.Error(err)
+        return
+    }
+
+    c.Status(http.StatusAccepted)
+}
+
+// ResetPassword godoc
+// @Summary      Redefinir senha
+// @Description  Consome o token recebido por email e define a nova senha. Todas as sessões (refresh tokens) do usuário são revogadas
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ResetPasswordRequest  true  "Token e nova senha"
+// @Success      204
+// @Failure      400      {object}  dto.ProblemDetails
+// @Failure      500      {object}  dto.ProblemDetails
+// @Router       /auth/reset-password [post]
+func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
+    var req dto.ResetPasswordRequest
+
+    if err := c.ShouldBindJSON(&req); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+
+    if err := h.resetService.ResetPassword(c.Request.Context(), req); err != nil {
+        _ = c.Error(err)
+        return
+    }
+
+    c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/service"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/mail"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/password"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+	"github.com/gin-gonic/gin"
+)
+
+func TestPasswordResetHandler_SpoofedForwardedForKeepsIPWindow(t *testing.T) {
+    // Arrange
+    gin.SetMode(gin.TestMode)
+    hasher, _ := password.NewHasher(password.Bcrypt)
+    resetService := service.NewPasswordResetService(
+        repository.NewUserRepository(repository.InMemory, nil),
+        repository.NewOneTimeTokenRepository(repository.InMemory, nil),
+        repository.NewRefreshTokenRepository(repository.InMemory, nil),
+        hasher,
+        mail.NewLogMailer(slog.Default()),
+        "http://localhost:3000/reset-password",
+        service.WithResetRateLimits(10, 1, time.Hour),
+    )
+    router := gin.New()
+    // Mesma configuração do main quando TRUSTED_PROXIES está vazio
+    if err := router.SetTrustedProxies(nil); err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    router.Use(ErrorHandler(testCatalog(t)))
+    NewPasswordResetHandler(resetService).RegisterRoutes(router)
+    
+    forgot := func(forwardedFor string) int {
+        req := httptest.NewRequest(http.MethodPost, "/auth/forgot-password", strings.NewReader(`{"email":"ninguem@email.com"}`))
+        req.Header.Set("Content-Type", "application/json")
+        req.Header.Set("X-Forwarded-For", forwardedFor)
+        rec := httptest.NewRecorder()
+        router.ServeHTTP(rec, req)
+        return rec.Code
+    }
+    
+    // Act
+    first := forgot("203.0.113.1")
+    second := forgot("203.0.113.2")
+    
+    // Assert: as duas vêm da mesma conexão, então contam para o mesmo IP
+    if first != http.StatusAccepted {
+        t.Fatalf("Expected 202 on the first request, got %d", first)
+    }
+    if second != http.StatusTooManyRequests {
+        t.Errorf("Expected 429 despite a new X-Forwarded-For, got %d", second)
+    }
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+    RequestIDHeader = "X-Request-ID"
+    
+    // ContextUserIDKey é preenchido pela autenticação com o ID do chamador.
+    ContextUserIDKey = "user_id"
+)
+
+// RequestLogger substitui o logger padrão do Gin por um registro estruturado
+// por requisição. Reaproveita o X-Request-ID do cliente ou gera um novo.
+func RequestLogger(logger *slog.Logger) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        start := time.Now()
+        
+        requestID := c.GetHeader(RequestIDHeader)
+        if requestID == "" {
+            requestID = uuid.New().String()
+        }
+        c.Header(RequestIDHeader, requestID)
+        c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
+        
+        c.Next()
+        
+        status := c.Writer.Status()
+        attrs := []slog.Attr{
+            slog.String("method", c.Request.Method),
+            slog.String("route", c.FullPath()),
+            slog.String("path", c.Request.URL.Path),
+            slog.Int("status", status),
+            slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+            slog.String("client_ip", c.ClientIP()),
+        }
+        if userID := c.GetString(ContextUserIDKey); userID != "" {
+            attrs = append(attrs, slog.String("user_id", userID))
+        }
+        if len(c.Errors) > 0 {
+            attrs = append(attrs, slog.String("error", c.Errors.Last().Error()))
+        }
+        
+        level := slog.LevelInfo
+        switch {
+        case status >= 500:
+            level = slog.LevelError
+        case status >= 400:
+            level = slog.LevelWarn
+        }
+        logger.LogAttrs(c.Request.Context(), level, "request completed", attrs...)
+    }
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/JoaoVitorFerreiro/golang-start/internal/infra/http"
+
+// Tracing abre o span raiz de cada requisição, continuando o trace do
+// cliente quando ele envia o header traceparent.
+func Tracing() gin.HandlerFunc {
+    tracer := otel.Tracer(tracerName)
+    
+    return func(c *gin.Context) {
+        ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+        
+        route := c.FullPath()
+        if route == "" {
+            route = "unmatched"
+        }
+        
+        ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+            trace.WithSpanKind(trace.SpanKindServer),
+            trace.WithAttributes(
+                semconv.HTTPRequestMethodKey.String(c.Request.Method),
+                semconv.HTTPRoute(route),
+                semconv.URLPath(c.Request.URL.Path),
+            ),
+        )
+        defer span.End()
+        
+        c.Request = c.Request.WithContext(ctx)
+        c.Next()
+        
+        status := c.Writer.Status()
+        span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+        if len(c.Errors) > 0 {
+            span.RecordError(c.Errors.Last().Err)
+        }
+        if status >= 500 {
+            span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+        }
+    }
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/service"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/broadcast"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+    DefaultHeartbeatInterval = 15 * time.Second
+    // reconnectDelay é o retry sugerido ao EventSource, em milissegundos
+    reconnectDelay = 3000
+)
+
+// UserEventsHandler serve /users/events em Server-Sent Events.
+type UserEventsHandler struct {
+    userService *service.UserService
+    heartbeat   time.Duration
+    // done encerra os streams abertos no shutdown; sem isso o
+    // server.Shutdown esperaria por conexões que nunca terminam
+    done        chan struct{}
+    closeOnce   sync.Once
+}
+
+func NewUserEventsHandler(userService *service.UserService, heartbeat time.Duration) *UserEventsHandler {
+    if heartbeat <= 0 {
+        heartbeat = DefaultHeartbeatInterval
+    }
+    return &UserEventsHandler{
+        userService: userService,
+        heartbeat:   heartbeat,
+        done:        make(chan struct{}),
+    }
+}
+
+// RegisterRoutes deve receber o mesmo grupo autenticado do UserHandler.
+func (h *UserEventsHandler) RegisterRoutes(router gin.IRouter) {
+    router.GET("/users/events", h.StreamEvents)
+}
+
+// Shutdown fecha todos os streams; os clientes reconectam em outra instância
+// com Last-Event-ID. Feito para server.RegisterOnShutdown.
+func (h *UserEventsHandler) Shutdown() {
+    h.closeOnce.Do(func() { close(h.done) })
+}
+
+// StreamEvents godoc
+// @Summary      Acompanhar alterações de usuários
+// @Description  Stream text/event-stream com os eventos user.created, user.updated (data é o usuário) e user.deleted (data traz o id). Ao reconectar, o Last-Event-ID retoma do ponto em que parou; se o evento já saiu do buffer, chega um evento reset e o cliente deve recarregar a lista. Comentários de heartbeat mantêm a conexão aberta. Um cliente lento demais é desconectado e retoma ao reconectar
+// @Tags         users
+// @Produce      text/event-stream
+// @Param        Last-Event-ID  header    string  false  "ID do último evento recebido"
+// @Success      200            {string}  string  "Stream de eventos"
+// @Failure      401            {object}  dto.ProblemDetails
+// @Failure      403            {object}  dto.ProblemDetails
+// @Failure      500            {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /users/events [get]
+func (h *UserEventsHandler) StreamEvents(c *gin.Context) {
+    ctx := c.Request.Context()
+    
+    sub, err := h.userService.SubscribeChanges(ctx, c.GetHeader("Last-Event-ID"))
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    defer sub.Close()
+    
+    c.Header("Content-Type", "text/event-stream")
+    c.Header("Cache-Control", "no-cache")
+    c.Header("Connection", "keep-alive")
+    // Desliga o buffer de proxies como o nginx, que seguraria os eventos
+    c.Header("X-Accel-Buffering", "no")
+    c.Status(http.StatusOK)
+    
+    if _, err := fmt.Fprintf(c.Writer, "retry: %d\n\n", reconnectDelay); err != nil {
+        return
+    }
+    for _, event := range sub.Replay {
+        if err := writeEvent(c.Writer, event); err != nil {
+            return
+        }
+    }
+    c.Writer.Flush()
+    
+    ticker := time.NewTicker(h.heartbeat)
+    defer ticker.Stop()
+    
+    for {
+        var err error
+        select {
+        case <-ctx.Done():
+            return
+        case <-h.done:
+            return
+        case <-sub.Done():
+            // Desconectado por não acompanhar: o cliente retoma do buffer
+            return
+        case event := <-sub.Events():
+            err = writeEvent(c.Writer, event)
+        case <-ticker.C:
+            _, err = io.WriteString(c.Writer, ": heartbeat\n\n")
+        }
+        if err != nil {
+            return
+        }
+        c.Writer.Flush()
+    }
+}
+
+// writeEvent escreve no formato do SSE. data é JSON compacto, sempre numa
+// linha só.
+func writeEvent(w io.Writer, event broadcast.Event) error {
+    _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+    return err
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/auth"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/service"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository"
+	"github.com/gin-gonic/gin"
+)
+
+func TestUserEventsHandler_StreamsChangesAndResumes(t *testing.T) {
+    // Arrange
+    gin.SetMode(gin.TestMode)
+    admin := &auth.Principal{ID: "admin", Roles: []string{auth.RoleAdmin}}
+    userService := service.NewUserService(repository.NewUserRepository(repository.InMemory, nil))
+    handler := NewUserEventsHandler(userService, time.Hour)
+    router := gin.New()
+    handler.RegisterRoutes(router.Group("", AllowAnonymous(admin)))
+    server := httptest.NewServer(router)
+    defer server.Close()
+    defer handler.Shutdown()
+    
+    ctx := auth.WithPrincipal(context.Background(), admin)
+    first, _ := userService.CreateUser(ctx, dto.CreateUserRequest{Name: "Ana Souza", Email: "ana@email.com"})
+    
+    // Act: conecta sem Last-Event-ID e recebe o próximo evento ao vivo
+    lines := openStream(t, server.URL+"/users/events", "")
+    second, _ := userService.CreateUser(ctx, dto.CreateUserRequest{Name: "Bruno Lima", Email: "bruno@email.com"})
+    live := readEvent(t, lines)
+    
+    // Assert
+    if live["event"] != "user.created" || !strings.Contains(live["data"], second.ID) {
+        t.Fatalf("Expected user.created for %s, got %v", second.ID, live)
+    }
+    if strings.Contains(live["data"], first.ID) {
+        t.Errorf("Expected no replay without Last-Event-ID, got %v", live)
+    }
+    
+    // Reconectando com o ID recebido, só vem o que veio depois
+    third, _ := userService.CreateUser(ctx, dto.CreateUserRequest{Name: "Carla Dias", Email: "carla@email.com"})
+    resumed := readEvent(t, openStream(t, server.URL+"/users/events", live["id"]))
+    if !strings.Contains(resumed["data"], third.ID) {
+        t.Errorf("Expected resume to replay %s, got %v", third.ID, resumed)
+    }
+}
+
+// openStream abre o stream e devolve suas linhas, fechando tudo no fim do teste.
+func openStream(t *testing.T, url, lastEventID string) <-chan string {
+    t.Helper()
+    ctx, cancel := context.WithCancel(context.Background())
+    t.Cleanup(cancel)
+    
+    req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+    if lastEventID != "" {
+        req.Header.Set("Last-Event-ID", lastEventID)
+    }
+    resp, err := http.DefaultClient.Do(req)
+    if err != nil {
+        t.Fatalf("Expected stream to open, got %v", err)
+    }
+    if ct := resp.Header.Get("Content-Type"); resp.StatusCode != http.StatusOK || ct != "text/event-stream" {
+        t.Fatalf("Expected 200 text/event-stream, got %d %s", resp.StatusCode, ct)
+    }
+    t.Cleanup(func() { resp.Body.Close() })
+    
+    lines := make(chan string)
+    go func() {
+        defer close(lines)
+        scanner := bufio.NewScanner(resp.Body)
+        for scanner.Scan() {
+            select {
+            case lines <- scanner.Text():
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+    return lines
+}
+
+// readEvent lê o próximo evento com id, ignorando retry e heartbeats.
+func readEvent(t *testing.T, lines <-chan string) map[string]string {
+    t.Helper()
+    fields := map[string]string{}
+    timeout := time.After(2 * time.Second)
+    for {
+        select {
+        case line, ok := <-lines:
+            if !ok {
+                t.Fatal("Stream closed before an event arrived")
+            }
+            if line == "" {
+                if fields["id"] != "" {
+                    return fields
+                }
+                continue
+            }
+            if name, value, found := strings.Cut(line, ": "); found {
+                fields[name] = value
+            }
+        case <-timeout:
+            t.Fatal("Timed out waiting for an event")
+        }
+    }
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
@@ -18,14 +19,17 @@ func NewUserHandler(userService *service.UserService) *UserHandler {
     }
 }
 
-func (h *UserHandler) RegisterRoutes(router *gin.Engine) {
+func (h *UserHandler) RegisterRoutes(router gin.IRouter) {
     userGroup := router.Group("/users")
     {
         userGroup.POST("", h.CreateUser)
         userGroup.GET("", h.GetAllUsers)
         userGroup.GET("/:id", h.GetUserByID)
         userGroup.PUT("/:id", h.UpdateUser)
+        userGroup.PATCH("/:id", h.PatchUser)
         userGroup.DELETE("/:id", h.DeleteUser)
+        userGroup.POST("/:id/verify-email", h.RequestEmailVerification)
+        userGroup.POST("/:id/restore", h.RestoreUser)
     }
 }
 
@@ -37,60 +41,75 @@ func (h *UserHandler) RegisterRoutes(router *gin.Engine) {
 // @Produce      json
 // @Param        user  body      dto.CreateUserRequest  true  "Dados do usuário"
 // @Success      201   {object}  dto.UserResponse
-// @Failure      400   {object}  dto.ErrorResponse
-// @Failure      409   {object}  dto.ErrorResponse
-// @Failure      500   {object}  dto.ErrorResponse
+// @Header       201   {string}  ETag  "Versão do usuário"
+// @Failure      400   {object}  dto.ProblemDetails
+// @Failure      401   {object}  dto.ProblemDetails
+// @Failure      403   {object}  dto.ProblemDetails
+// @Failure      409   {object}  dto.ProblemDetails
+// @Failure      500   {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /users [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
     var req dto.CreateUserRequest
     
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-            Error:   "invalid request",
-            Message: err.Error(),
-        })
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
         return
     }
     
-    user, err := h.userService.CreateUser(req)
+    user, err := h.userService.CreateUser(c.Request.Context(), req)
     if err != nil {
-        if err.Error() == "email already exists" {
-            c.JSON(http.StatusConflict, dto.ErrorResponse{
-                Error:   "email already exists",
-                Message: "Um usuário com este email já existe",
-            })
-            return
-        }
-        
-        c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-            Error:   "internal server error",
-            Message: "Erro interno do servidor",
-        })
+        _ = c.Error(err)
         return
     }
     
+    setETag(c, user.Version)
     c.JSON(http.StatusCreated, user)
 }
 
 // GetAllUsers godoc
 // @Summary      Listar usuários
-// @Description  Retorna todos os usuários cadastrados
+// @Description  Retorna os usuários paginados por cursor. A próxima página é indicada em next_cursor e no header Link
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Success      200  {array}   dto.UserResponse
-// @Failure      500  {object}  dto.ErrorResponse
+// @Param        limit           query     int     false  "Quantidade de itens por página (1-100)"  default(20)
+// @Param        cursor          query     string  false  "Cursor opaco retornado em next_cursor; só vale com o mesmo sort"
+// @Param        sort            query     string  false  "Ordenação"  Enums(created_at, -created_at)  default(-created_at)
+// @Param        name_prefix     query     string  false  "Filtra pelo prefixo do nome"
+// @Param        email_prefix    query     string  false  "Filtra pelo prefixo do email"
+// @Param        created_after   query     string  false  "Criados a partir de (RFC 3339)"
+// @Param        created_before  query     string  false  "Criados antes de (RFC 3339)"
+// @Success      200  {object}  dto.UserListResponse
+// @Header       200  {string}  Link  "Link para a próxima página (rel=next)"
+// @Failure      400  {object}  dto.ProblemDetails
+// @Failure      401  {object}  dto.ProblemDetails
+// @Failure      403  {object}  dto.ProblemDetails
+// @Failure      500  {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /users [get]
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
-    users, err := h.userService.GetAllUsers()
+    var query dto.ListUsersQuery
+    
+    if err := c.ShouldBindQuery(&query); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+    
+    users, err := h.userService.GetAllUsers(c.Request.Context(), query)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-            Error:   "internal server error",
-            Message: "Erro ao buscar usuários",
-        })
+        _ = c.Error(err)
         return
     }
     
+    if users.NextCursor != "" {
+        next := c.Request.URL.Query()
+        next.Set("cursor", users.NextCursor)
+        c.Header("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, c.Request.URL.Path, next.Encode()))
+    }
+    
     c.JSON(http.StatusOK, users)
 }
 
@@ -102,107 +121,202 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 // @Produce      json
 // @Param        id   path      string  true  "ID do usuário"
 // @Success      200  {object}  dto.UserResponse
-// @Failure      404  {object}  dto.ErrorResponse
-// @Failure      500  {object}  dto.ErrorResponse
+// @Header       200  {string}  ETag  "Versão do usuário, para usar em If-Match"
+// @Failure      401  {object}  dto.ProblemDetails
+// @Failure      403  {object}  dto.ProblemDetails
+// @Failure      404  {object}  dto.ProblemDetails
+// @Failure      500  {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /users/{id} [get]
 func (h *UserHandler) GetUserByID(c *gin.Context) {
     id := c.Param("id")
     
-    user, err := h.userService.GetUserByID(id)
+    user, err := h.userService.GetUserByID(c.Request.Context(), id)
     if err != nil {
-        if err.Error() == "user not found" {
-            c.JSON(http.StatusNotFound, dto.ErrorResponse{
-                Error:   "user not found",
-                Message: "Usuário não encontrado",
-            })
-            return
-        }
-        
-        c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-            Error:   "internal server error",
-            Message: "Erro interno do servidor",
-        })
+        _ = c.Error(err)
         return
     }
     
+    setETag(c, user.Version)
     c.JSON(http.StatusOK, user)
 }
 
 // UpdateUser godoc
-// @Summary      Atualizar usuário
-// @Description  Atualiza os dados de um usuário existente
+// @Summary      Substituir usuário
+// @Description  Substitui nome e email do usuário; os dois campos são obrigatórios. Para alterações parciais use PATCH. Com If-Match, só atualiza se a versão atual for a informada
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Param        id    path      string                 true  "ID do usuário"
-// @Param        user  body      dto.UpdateUserRequest  true  "Dados para atualização"
-// @Success      200   {object}  dto.UserResponse
-// @Failure      400   {object}  dto.ErrorResponse
-// @Failure      404   {object}  dto.ErrorResponse
-// @Failure      500   {object}  dto.ErrorResponse
+// @Param        id        path      string                 true   "ID do usuário"
+// @Param        If-Match  header    string                 false  "ETag obtido no GET"
+// @Param        user      body      dto.UpdateUserRequest  true   "Dados para atualização"
+// @Success      200       {object}  dto.UserResponse
+// @Header       200       {string}  ETag  "Nova versão do usuário"
+// @Failure      400       {object}  dto.ProblemDetails
+// @Failure      401       {object}  dto.ProblemDetails
+// @Failure      403       {object}  dto.ProblemDetails
+// @Failure      404       {object}  dto.ProblemDetails
+// @Failure      409       {object}  dto.ProblemDetails
+// @Failure      412       {object}  dto.ProblemDetails
+// @Failure      500       {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
     id := c.Param("id")
     var req dto.UpdateUserRequest
     
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-            Error:   "invalid request",
-            Message: err.Error(),
-        })
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
         return
     }
     
-    user, err := h.userService.UpdateUser(id, req)
+    user, err := h.userService.UpdateUser(c.Request.Context(), id, req, parseIfMatch(c))
     if err != nil {
-        if err.Error() == "user not found" {
-            c.JSON(http.StatusNotFound, dto.ErrorResponse{
-                Error:   "user not found",
-                Message: "Usuário não encontrado",
-            })
-            return
-        }
-        
-        c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-            Error:   "internal server error",
-            Message: "Erro interno do servidor",
-        })
+        _ = c.Error(err)
         return
     }
     
+    setETag(c, user.Version)
+    c.JSON(http.StatusOK, user)
+}
+
+// PatchUser godoc
+// @Summary      Atualizar usuário parcialmente
+// @Description  Aplica um JSON Merge Patch (RFC 7396) ou um JSON Patch (RFC 6902, inclusive test) sobre a representação do usuário. Só name e email podem ser alterados. Com If-Match, só atualiza se a versão atual for a informada
+// @Tags         users
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
+// @Produce      json
+// @Param        id        path      string  true   "ID do usuário"
+// @Param        If-Match  header    string  false  "ETag obtido no GET"
+// @Param        patch     body      object  true   "Documento de patch"
+// @Success      200       {object}  dto.UserResponse
+// @Header       200       {string}  ETag  "Nova versão do usuário"
+// @Failure      400       {object}  dto.ProblemDetails
+// @Failure      401       {object}  dto.ProblemDetails
+// @Failure      403       {object}  dto.ProblemDetails
+// @Failure      404       {object}  dto.ProblemDetails
+// @Failure      409       {object}  dto.ProblemDetails
+// @Failure      412       {object}  dto.ProblemDetails
+// @Failure      415       {object}  dto.ProblemDetails
+// @Failure      422       {object}  dto.ProblemDetails
+// @Failure      500       {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /users/{id} [patch]
+func (h *UserHandler) PatchUser(c *gin.Context) {
+    id := c.Param("id")
+    
+    document, err := c.GetRawData()
+    if err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+    
+    req := dto.PatchUserRequest{ContentType: c.ContentType(), Document: document}
+    user, err := h.userService.PatchUser(c.Request.Context(), id, req, parseIfMatch(c))
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    setETag(c, user.Version)
     c.JSON(http.StatusOK, user)
 }
 
 // DeleteUser godoc
 // @Summary      Deletar usuário
-// @Description  Remove um usuário do sistema
+// @Description  Exclui o usuário logicamente; ele pode ser restaurado até o expurgo. Com permanent=true (só admin) remove definitivamente
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Param        id  path  string  true  "ID do usuário"
+// @Param        id         path    string  true   "ID do usuário"
+// @Param        permanent  query   bool    false  "Remove definitivamente, sem possibilidade de restauração"
+// @Param        If-Match   header  string  false  "ETag obtido no GET"
 // @Success      204
-// @Failure      404  {object}  dto.ErrorResponse
-// @Failure      500  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ProblemDetails
+// @Failure      403  {object}  dto.ProblemDetails
+// @Failure      404  {object}  dto.ProblemDetails
+// @Failure      409  {object}  dto.ProblemDetails
+// @Failure      412  {object}  dto.ProblemDetails
+// @Failure      500  {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
     id := c.Param("id")
     
-    err := h.userService.DeleteUser(id)
+    precondition := parseIfMatch(c)
+    
+    var err error
+    if c.Query("permanent") == "true" {
+        err = h.userService.PurgeUser(c.Request.Context(), id, precondition)
+    } else {
+        err = h.userService.DeleteUser(c.Request.Context(), id, precondition)
+    }
     if err != nil {
-        if err.Error() == "user not found" {
-            c.JSON(http.StatusNotFound, dto.ErrorResponse{
-                Error:   "user not found",
-                Message: "Usuário não encontrado",
-            })
-            return
-        }
-        
-        c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-            Error:   "internal server error",
-            Message: "Erro interno do servidor",
-        })
+        _ = c.Error(err)
         return
     }
     
     c.Status(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// RestoreUser godoc
+// @Summary      Restaurar usuário
+// @Description  Desfaz a exclusão lógica de um usuário (só admin)
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID do usuário"
+// @Success      200  {object}  dto.UserResponse
+// @Header       200  {string}  ETag  "Versão do usuário"
+// @Failure      401  {object}  dto.ProblemDetails
+// @Failure      403  {object}  dto.ProblemDetails
+// @Failure      404  {object}  dto.ProblemDetails
+// @Failure      409  {object}  dto.ProblemDetails
+// @Failure      500  {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+    id := c.Param("id")
+    
+    user, err := h.userService.RestoreUser(c.Request.Context(), id)
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    setETag(c, user.Version)
+    c.JSON(http.StatusOK, user)
+}
+
+// RequestEmailVerification godoc
+// @Summary      Reenviar verificação de email
+// @Description  Envia um novo link de verificação para o email atual do usuário. Links anteriores deixam de valer
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID do usuário"
+// @Success      202
+// @Failure      401  {object}  dto.ProblemDetails
+// @Failure      403  {object}  dto.ProblemDetails
+// @Failure      404  {object}  dto.ProblemDetails
+// @Failure      409  {object}  dto.ProblemDetails
+// @Failure      500  {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /users/{id}/verify-email [post]
+func (h *UserHandler) RequestEmailVerification(c *gin.Context) {
+    id := c.Param("id")
+    
+    if err := h.userService.RequestEmailVerification(c.Request.Context(), id); err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    c.Status(http.StatusAccepted)
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+var registerFieldNamesOnce sync.Once
+
+// registerFieldNames faz o validator reportar os campos pelo nome que o
+// cliente usa (tag json, form ou uri) em vez do nome do campo Go.
+func registerFieldNames() {
+    registerFieldNamesOnce.Do(func() {
+        v, ok := binding.Validator.Engine().(*validator.Validate)
+        if !ok {
+            return
+        }
+        v.RegisterTagNameFunc(func(f reflect.StructField) string {
+            for _, tag := range []string{"json", "form", "uri"} {
+                name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+                if name == "-" {
+                    return ""
+                }
+                if name != "" {
+                    return name
+                }
+            }
+            return f.Name
+        })
+    })
+}
+
+// bindingErrors classifica uma falha de binding em violações por campo,
+// sem repassar o texto interno do validator. detailKey escolhe o detalhe
+// do problema no catálogo.
+func bindingErrors(err error) (detailKey string, violations []fieldViolation) {
+    var validationErrs validator.ValidationErrors
+    var typeErr *json.UnmarshalTypeError
+    var syntaxErr *json.SyntaxError
+    
+    switch {
+    case errors.As(err, &validationErrs):
+        for _, fe := range validationErrs {
+            violations = append(violations, fieldViolation{
+                field:      fe.Field(),
+                code:       fe.Tag(),
+                messageKey: validationMessageKey(fe),
+                param:      strings.ReplaceAll(fe.Param(), " ", ", "),
+            })
+        }
+        return "", violations
+    case errors.As(err, &typeErr):
+        return "", []fieldViolation{{
+            field:      typeErr.Field,
+            code:       "type",
+            messageKey: "field.type",
+            param:      jsonTypeName(typeErr.Type),
+            paramKey:   true,
+        }}
+    case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+        return "problem.invalid-request.detail.invalid-json", nil
+    default:
+        return "problem.invalid-request.detail.unparsable", nil
+    }
+}
+
+// validationMessageKey escolhe a mensagem no catálogo; o código exposto
+// continua sendo a tag do validator.
+func validationMessageKey(fe validator.FieldError) string {
+    switch fe.Tag() {
+    case "required", "email", "oneof":
+        return "field." + fe.Tag()
+    case "min", "max":
+        if fe.Kind() == reflect.String {
+            return "field." + fe.Tag() + "_length"
+        }
+        return "field." + fe.Tag()
+    default:
+        return "field.invalid"
+    }
+}
+
+// jsonTypeName devolve a chave do catálogo com o nome do tipo JSON esperado.
+func jsonTypeName(t reflect.Type) string {
+    switch t.Kind() {
+    case reflect.String:
+        return "jsontype.string"
+    case reflect.Bool:
+        return "jsontype.boolean"
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+        reflect.Float32, reflect.Float64:
+        return "jsontype.number"
+    case reflect.Slice, reflect.Array:
+        return "jsontype.array"
+    default:
+        return "jsontype.object"
+    }
+}
@@ -0,0 +1,227 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/dto"
+	"github.com/JoaoVitorFerreiro/golang-start/internal/application/service"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+    webhookService *service.WebhookService
+}
+
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+    return &WebhookHandler{
+        webhookService: webhookService,
+    }
+}
+
+func (h *WebhookHandler) RegisterRoutes(router gin.IRouter) {
+    webhookGroup := router.Group("/webhooks")
+    {
+        webhookGroup.POST("", h.CreateWebhook)
+        webhookGroup.GET("", h.ListWebhooks)
+        webhookGroup.GET("/:id", h.GetWebhook)
+        webhookGroup.PUT("/:id", h.UpdateWebhook)
+        webhookGroup.DELETE("/:id", h.DeleteWebhook)
+        webhookGroup.GET("/:id/deliveries", h.ListDeliveries)
+        webhookGroup.GET("/:id/deliveries/:deliveryId", h.GetDelivery)
+    }
+}
+
+// CreateWebhook godoc
+// @Summary      Criar webhook
+// @Description  Inscreve uma URL para receber os eventos de usuário (só admin). Cada entrega é um POST com o envelope do evento, assinado com HMAC-SHA256 do segredo sobre "<X-Webhook-Timestamp>.<corpo>" no header X-Webhook-Signature (v1=<hex>). O segredo só é devolvido nesta resposta
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      dto.CreateWebhookRequest  true  "Dados da inscrição"
+// @Success      201      {object}  dto.WebhookResponse
+// @Failure      400      {object}  dto.ProblemDetails
+// @Failure      401      {object}  dto.ProblemDetails
+// @Failure      403      {object}  dto.ProblemDetails
+// @Failure      500      {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+    var req dto.CreateWebhookRequest
+    
+    if err := c.ShouldBindJSON(&req); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+    
+    webhook, err := h.webhookService.CreateWebhook(c.Request.Context(), req)
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    c.JSON(http.StatusCreated, webhook)
+}
+
+// ListWebhooks godoc
+// @Summary      Listar webhooks
+// @Description  Retorna todas as inscrições, sem os segredos (só admin)
+// @Tags         webhooks
+// @Produce      json
+// @Success      200  {object}  dto.WebhookListResponse
+// @Failure      401  {object}  dto.ProblemDetails
+// @Failure      403  {object}  dto.ProblemDetails
+// @Failure      500  {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+    webhooks, err := h.webhookService.ListWebhooks(c.Request.Context())
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+// @Summary      Buscar webhook por ID
+// @Description  Retorna uma inscrição, sem o segredo (só admin)
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      string  true  "ID do webhook"
+// @Success      200  {object}  dto.WebhookResponse
+// @Failure      401  {object}  dto.ProblemDetails
+// @Failure      403  {object}  dto.ProblemDetails
+// @Failure      404  {object}  dto.ProblemDetails
+// @Failure      500  {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+    webhook, err := h.webhookService.GetWebhook(c.Request.Context(), c.Param("id"))
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary      Substituir webhook
+// @Description  Substitui URL, eventos, descrição e estado da inscrição (só admin). O segredo não muda. Entregas pendentes de uma inscrição desativada não são feitas
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "ID do webhook"
+// @Param        webhook  body      dto.UpdateWebhookRequest  true  "Nova configuração"
+// @Success      200      {object}  dto.WebhookResponse
+// @Failure      400      {object}  dto.ProblemDetails
+// @Failure      401      {object}  dto.ProblemDetails
+// @Failure      403      {object}  dto.ProblemDetails
+// @Failure      404      {object}  dto.ProblemDetails
+// @Failure      500      {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+    var req dto.UpdateWebhookRequest
+    
+    if err := c.ShouldBindJSON(&req); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+    
+    webhook, err := h.webhookService.UpdateWebhook(c.Request.Context(), c.Param("id"), req)
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary      Deletar webhook
+// @Description  Remove a inscrição e o histórico de entregas (só admin)
+// @Tags         webhooks
+// @Produce      json
+// @Param        id  path  string  true  "ID do webhook"
+// @Success      204
+// @Failure      401  {object}  dto.ProblemDetails
+// @Failure      403  {object}  dto.ProblemDetails
+// @Failure      404  {object}  dto.ProblemDetails
+// @Failure      500  {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+    if err := h.webhookService.DeleteWebhook(c.Request.Context(), c.Param("id")); err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary      Listar entregas do webhook
+// @Description  Retorna as entregas da inscrição, da mais nova para a mais antiga, paginadas por cursor (só admin)
+// @Tags         webhooks
+// @Produce      json
+// @Param        id      path      string  true   "ID do webhook"
+// @Param        limit   query     int     false  "Quantidade de itens por página (1-100)"  default(20)
+// @Param        cursor  query     string  false  "Cursor opaco retornado em next_cursor"
+// @Param        status  query     string  false  "Filtra pela situação"  Enums(pending, succeeded, failed)
+// @Success      200     {object}  dto.WebhookDeliveryListResponse
+// @Failure      400     {object}  dto.ProblemDetails
+// @Failure      401     {object}  dto.ProblemDetails
+// @Failure      403     {object}  dto.ProblemDetails
+// @Failure      404     {object}  dto.ProblemDetails
+// @Failure      500     {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+    var query dto.ListDeliveriesQuery
+    
+    if err := c.ShouldBindQuery(&query); err != nil {
+        _ = c.Error(err).SetType(gin.ErrorTypeBind)
+        return
+    }
+    
+    deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), c.Param("id"), query)
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    c.JSON(http.StatusOK, deliveries)
+}
+
+// GetDelivery godoc
+// @Summary      Buscar entrega do webhook
+// @Description  Retorna a entrega com todas as tentativas feitas (só admin)
+// @Tags         webhooks
+// @Produce      json
+// @Param        id          path      string  true  "ID do webhook"
+// @Param        deliveryId  path      string  true  "ID da entrega"
+// @Success      200         {object}  dto.WebhookDeliveryResponse
+// @Failure      401         {object}  dto.ProblemDetails
+// @Failure      403         {object}  dto.ProblemDetails
+// @Failure      404         {object}  dto.ProblemDetails
+// @Failure      500         {object}  dto.ProblemDetails
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhooks/{id}/deliveries/{deliveryId} [get]
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+    delivery, err := h.webhookService.GetDelivery(c.Request.Context(), c.Param("id"), c.Param("deliveryId"))
+    if err != nil {
+        _ = c.Error(err)
+        return
+    }
+    
+    c.JSON(http.StatusOK, delivery)
+}
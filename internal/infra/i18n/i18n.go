@@ -0,0 +1,123 @@
+// Package i18n guarda os catálogos de mensagens da API (embutidos no
+// binário) e escolhe o idioma a partir do header Accept-Language.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Catalog contém as mensagens de todos os idiomas suportados. Chaves que
+// faltam num idioma caem no idioma padrão e, por fim, na própria chave.
+type Catalog struct {
+    fallback  language.Tag
+    supported []language.Tag
+    matcher   language.Matcher
+    messages  map[language.Tag]map[string]string
+}
+
+// NewCatalog carrega os arquivos embutidos. fallback (ex.: "pt-BR") é usado
+// quando o cliente não pede nenhum idioma suportado e precisa existir.
+func NewCatalog(fallback string) (*Catalog, error) {
+    fallbackTag, err := language.Parse(fallback)
+    if err != nil {
+        return nil, fmt.Errorf("parse fallback locale: %w", err)
+    }
+    
+    entries, err := localeFiles.ReadDir("locales")
+    if err != nil {
+        return nil, err
+    }
+    
+    c := &Catalog{fallback: fallbackTag, messages: map[language.Tag]map[string]string{}}
+    // O primeiro da lista é o escolhido quando nada casa
+    c.supported = []language.Tag{fallbackTag}
+    for _, entry := range entries {
+        name := strings.TrimSuffix(entry.Name(), ".json")
+        tag, err := language.Parse(name)
+        if err != nil {
+            return nil, fmt.Errorf("locale file %s: %w", entry.Name(), err)
+        }
+        data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+        if err != nil {
+            return nil, err
+        }
+        var messages map[string]string
+        if err := json.Unmarshal(data, &messages); err != nil {
+            return nil, fmt.Errorf("locale file %s: %w", entry.Name(), err)
+        }
+        c.messages[tag] = messages
+        if tag != fallbackTag {
+            c.supported = append(c.supported, tag)
+        }
+    }
+    if _, ok := c.messages[fallbackTag]; !ok {
+        return nil, fmt.Errorf("fallback locale %s has no catalog", fallback)
+    }
+    
+    c.matcher = language.NewMatcher(c.supported)
+    return c, nil
+}
+
+// Negotiate escolhe o idioma suportado que melhor atende ao Accept-Language.
+func (c *Catalog) Negotiate(acceptLanguage string) Localizer {
+    tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+    if err != nil || len(tags) == 0 {
+        return Localizer{catalog: c, tag: c.fallback}
+    }
+    _, index, confidence := c.matcher.Match(tags...)
+    if confidence == language.No {
+        return Localizer{catalog: c, tag: c.fallback}
+    }
+    return Localizer{catalog: c, tag: c.supported[index]}
+}
+
+// Localizer traduz mensagens para um idioma já negociado.
+type Localizer struct {
+    catalog *Catalog
+    tag     language.Tag
+}
+
+// Language devolve o idioma em formato BCP 47, para o header Content-Language.
+func (l Localizer) Language() string {
+    return l.tag.String()
+}
+
+// Message devolve a mensagem da chave com os {placeholders} substituídos
+// por args, dados em pares nome, valor.
+func (l Localizer) Message(key string, args ...string) string {
+    message, ok := l.lookup(key)
+    if !ok {
+        return key
+    }
+    if len(args) > 1 {
+        pairs := make([]string, 0, len(args))
+        for i := 0; i+1 < len(args); i += 2 {
+            pairs = append(pairs, "{"+args[i]+"}", args[i+1])
+        }
+        message = strings.NewReplacer(pairs...).Replace(message)
+    }
+    return message
+}
+
+// Has diz se a chave existe no idioma ou no padrão.
+func (l Localizer) Has(key string) bool {
+    _, ok := l.lookup(key)
+    return ok
+}
+
+func (l Localizer) lookup(key string) (string, bool) {
+    if message, ok := l.catalog.messages[l.tag][key]; ok {
+        return message, true
+    }
+    message, ok := l.catalog.messages[l.catalog.fallback][key]
+    return message, ok
+}
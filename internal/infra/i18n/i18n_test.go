@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"encoding/json"
+	"path"
+	"testing"
+)
+
+func TestNewCatalog_LocalesHaveTheSameKeys(t *testing.T) {
+    // Arrange
+    entries, err := localeFiles.ReadDir("locales")
+    if err != nil {
+        t.Fatal(err)
+    }
+    catalogs := map[string]map[string]string{}
+    for _, entry := range entries {
+        data, _ := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+        var messages map[string]string
+        if err := json.Unmarshal(data, &messages); err != nil {
+            t.Fatalf("%s: %v", entry.Name(), err)
+        }
+        catalogs[entry.Name()] = messages
+    }
+    
+    // Assert
+    reference := catalogs["pt-BR.json"]
+    for name, messages := range catalogs {
+        for key := range reference {
+            if _, ok := messages[key]; !ok {
+                t.Errorf("%s is missing key %s", name, key)
+            }
+        }
+        for key := range messages {
+            if _, ok := reference[key]; !ok {
+                t.Errorf("%s has key %s not present in pt-BR.json", name, key)
+            }
+        }
+    }
+}
+
+func TestCatalog_Negotiate(t *testing.T) {
+    // Arrange
+    catalog, err := NewCatalog("pt-BR")
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    
+    cases := map[string]string{
+        "":                        "pt-BR",
+        "en":                      "en",
+        "en-GB,en;q=0.8":          "en",
+        "es-MX":                   "es",
+        "pt":                      "pt-BR",
+        "de-DE,fr;q=0.9":          "pt-BR",
+        "de-DE,es;q=0.5,en;q=0.7": "en",
+        "not a language tag!!":    "pt-BR",
+    }
+    
+    for header, want := range cases {
+        // Act
+        got := catalog.Negotiate(header).Language()
+        
+        // Assert
+        if got != want {
+            t.Errorf("Negotiate(%q) = %s, want %s", header, got, want)
+        }
+    }
+}
+
+func TestLocalizer_Message(t *testing.T) {
+    // Arrange
+    catalog, _ := NewCatalog("en")
+    l := catalog.Negotiate("es")
+    
+    // Act & Assert
+    if got := l.Message("field.min", "field", "limit", "param", "1"); got != "El campo limit debe ser como mínimo 1" {
+        t.Errorf("Unexpected message %q", got)
+    }
+    if got := l.Message("missing.key"); got != "missing.key" {
+        t.Errorf("Expected unknown keys to fall back to the key, got %q", got)
+    }
+}
+
+func TestNewCatalog_UnknownFallback(t *testing.T) {
+    if _, err := NewCatalog("de"); err == nil {
+        t.Error("Expected an error for a fallback locale without catalog")
+    }
+}
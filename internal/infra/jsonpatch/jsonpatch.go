@@ -0,0 +1,102 @@
+// Package jsonpatch aplica documentos de patch sobre JSON genérico:
+// JSON Merge Patch (RFC 7396) e JSON Patch (RFC 6902).
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+    MediaTypeMergePatch = "application/merge-patch+json"
+    MediaTypeJSONPatch  = "application/json-patch+json"
+)
+
+// AcceptPatch é o valor do header Accept-Patch (RFC 5789).
+const AcceptPatch = MediaTypeMergePatch + ", " + MediaTypeJSONPatch
+
+var (
+    ErrUnsupportedMediaType = errors.New("unsupported patch media type")
+    // ErrInvalidPatch indica um documento de patch malformado
+    ErrInvalidPatch = errors.New("invalid patch document")
+    // ErrPathNotFound indica uma operação sobre um caminho inexistente
+    ErrPathNotFound = errors.New("path not found")
+    ErrTestFailed   = errors.New("test operation failed")
+)
+
+// OperationError identifica qual operação do JSON Patch falhou.
+type OperationError struct {
+    Index int
+    Op    string
+    Path  string
+    Err   error
+}
+
+func (e *OperationError) Error() string {
+    return fmt.Sprintf("operation %d (%s %s): %v", e.Index, e.Op, e.Path, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+    return e.Err
+}
+
+// Apply escolhe o formato pelo Content-Type (sem parâmetros).
+func Apply(mediaType string, doc, patch []byte) ([]byte, error) {
+    switch mediaType {
+    case MediaTypeMergePatch:
+        return MergePatch(doc, patch)
+    case MediaTypeJSONPatch:
+        return ApplyPatch(doc, patch)
+    default:
+        return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mediaType)
+    }
+}
+
+// MergePatch aplica um JSON Merge Patch: objetos são mesclados
+// recursivamente, null remove a chave e qualquer outro valor substitui.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+    target, err := decode(doc)
+    if err != nil {
+        return nil, err
+    }
+    p, err := decode(patch)
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+    }
+    return json.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch any) any {
+    p, ok := patch.(map[string]any)
+    if !ok {
+        return patch
+    }
+    t, ok := target.(map[string]any)
+    if !ok {
+        t = map[string]any{}
+    }
+    for key, value := range p {
+        if value == nil {
+            delete(t, key)
+            continue
+        }
+        t[key] = mergePatch(t[key], value)
+    }
+    return t
+}
+
+func decode(data []byte) (any, error) {
+    dec := json.NewDecoder(bytes.NewReader(data))
+    dec.UseNumber()
+    
+    var v any
+    if err := dec.Decode(&v); err != nil {
+        return nil, err
+    }
+    if dec.More() {
+        return nil, errors.New("unexpected data after JSON value")
+    }
+    return v, nil
+}
@@ -0,0 +1,113 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func assertJSON(t *testing.T, got []byte, want string) {
+    t.Helper()
+    var g, w any
+    if err := json.Unmarshal(got, &g); err != nil {
+        t.Fatalf("invalid JSON result %s: %v", got, err)
+    }
+    if err := json.Unmarshal([]byte(want), &w); err != nil {
+        t.Fatalf("invalid expected JSON %s: %v", want, err)
+    }
+    if !reflect.DeepEqual(g, w) {
+        t.Errorf("Expected %s, got %s", want, got)
+    }
+}
+
+func TestMergePatch(t *testing.T) {
+    // Exemplos do apêndice A da RFC 7396
+    cases := []struct {
+        doc, patch, want string
+    }{
+        {`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+        {`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+        {`{"a":"b"}`, `{"a":null}`, `{}`},
+        {`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+        {`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+        {`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+        {`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+        {`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+        {`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+        {`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+    }
+    
+    for _, tc := range cases {
+        got, err := MergePatch([]byte(tc.doc), []byte(tc.patch))
+        if err != nil {
+            t.Fatalf("MergePatch(%s, %s): %v", tc.doc, tc.patch, err)
+        }
+        assertJSON(t, got, tc.want)
+    }
+}
+
+func TestApplyPatch(t *testing.T) {
+    // Exemplos do apêndice A da RFC 6902
+    cases := []struct {
+        name, doc, patch, want string
+    }{
+        {"add object member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+        {"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+        {"append to array", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"qux"}]`, `{"foo":["bar","qux"]}`},
+        {"remove object member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+        {"remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+        {"replace", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+        {"move", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+            `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+        {"move array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+        {"copy", `{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"}]`, `{"foo":{"bar":1},"baz":{"bar":1}}`},
+        {"test then replace", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0},{"op":"replace","path":"/baz","value":null}]`,
+            `{"baz":null,"foo":["a",2,"c"]}`},
+        {"escaped pointer", `{"a/b":1,"m~n":2}`, `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, `{"a/b":3}`},
+        {"add nested member", `{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            got, err := ApplyPatch([]byte(tc.doc), []byte(tc.patch))
+            if err != nil {
+                t.Fatalf("Expected no error, got %v", err)
+            }
+            assertJSON(t, got, tc.want)
+        })
+    }
+}
+
+func TestApplyPatch_Errors(t *testing.T) {
+    cases := []struct {
+        name, doc, patch string
+        want             error
+    }{
+        {"test failed", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, ErrTestFailed},
+        {"missing target", `{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, ErrPathNotFound},
+        {"replace missing member", `{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, ErrPathNotFound},
+        {"index out of range", `{"foo":[1]}`, `[{"op":"add","path":"/foo/5","value":2}]`, ErrPathNotFound},
+        {"leading zero index", `{"foo":[1,2]}`, `[{"op":"remove","path":"/foo/01"}]`, ErrPathNotFound},
+        {"unknown op", `{}`, `[{"op":"merge","path":"/a","value":1}]`, ErrInvalidPatch},
+        {"missing value", `{}`, `[{"op":"add","path":"/a"}]`, ErrInvalidPatch},
+        {"move into child", `{"a":{"b":1}}`, `[{"op":"move","from":"/a","path":"/a/c"}]`, ErrInvalidPatch},
+        {"not an array", `{}`, `{"op":"add"}`, ErrInvalidPatch},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            _, err := ApplyPatch([]byte(tc.doc), []byte(tc.patch))
+            if !errors.Is(err, tc.want) {
+                t.Errorf("Expected %v, got %v", tc.want, err)
+            }
+        })
+    }
+}
+
+func TestApply_UnsupportedMediaType(t *testing.T) {
+    _, err := Apply("application/json", []byte(`{}`), []byte(`{}`))
+    if !errors.Is(err, ErrUnsupportedMediaType) {
+        t.Errorf("Expected ErrUnsupportedMediaType, got %v", err)
+    }
+}
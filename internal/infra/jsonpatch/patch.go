@@ -0,0 +1,344 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type operation struct {
+    Op    string          `json:"op"`
+    Path  *string         `json:"path"`
+    From  *string         `json:"from"`
+    Value json.RawMessage `json:"value"`
+}
+
+// ApplyPatch aplica as operações de um JSON Patch em ordem. Se qualquer uma
+// falhar, nada é aplicado e o erro é um *OperationError.
+func ApplyPatch(doc, patch []byte) ([]byte, error) {
+    var ops []operation
+    if err := json.Unmarshal(patch, &ops); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+    }
+    
+    target, err := decode(doc)
+    if err != nil {
+        return nil, err
+    }
+    for i, op := range ops {
+        path := ""
+        if op.Path != nil {
+            path = *op.Path
+        }
+        target, err = op.apply(target)
+        if err != nil {
+            return nil, &OperationError{Index: i, Op: op.Op, Path: path, Err: err}
+        }
+    }
+    return json.Marshal(target)
+}
+
+func (op operation) apply(doc any) (any, error) {
+    if op.Path == nil {
+        return nil, fmt.Errorf("%w: missing path", ErrInvalidPatch)
+    }
+    path, err := parsePointer(*op.Path)
+    if err != nil {
+        return nil, err
+    }
+    
+    switch op.Op {
+    case "add", "replace", "test":
+        value, err := op.value()
+        if err != nil {
+            return nil, err
+        }
+        switch op.Op {
+        case "add":
+            return add(doc, path, value)
+        case "replace":
+            return replace(doc, path, value)
+        default:
+            current, err := get(doc, path)
+            if err != nil {
+                return nil, err
+            }
+            if !equal(current, value) {
+                return nil, ErrTestFailed
+            }
+            return doc, nil
+        }
+    case "remove":
+        doc, _, err := remove(doc, path)
+        return doc, err
+    case "move", "copy":
+        if op.From == nil {
+            return nil, fmt.Errorf("%w: missing from", ErrInvalidPatch)
+        }
+        from, err := parsePointer(*op.From)
+        if err != nil {
+            return nil, err
+        }
+        if op.Op == "copy" {
+            value, err := get(doc, from)
+            if err != nil {
+                return nil, err
+            }
+            value, err = deepCopy(value)
+            if err != nil {
+                return nil, err
+            }
+            return add(doc, path, value)
+        }
+        if isProperPrefix(from, path) {
+            return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalidPatch)
+        }
+        doc, value, err := remove(doc, from)
+        if err != nil {
+            return nil, err
+        }
+        return add(doc, path, value)
+    default:
+        return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+    }
+}
+
+func (op operation) value() (any, error) {
+    if op.Value == nil {
+        return nil, fmt.Errorf("%w: missing value", ErrInvalidPatch)
+    }
+    v, err := decode(op.Value)
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+    }
+    return v, nil
+}
+
+// parsePointer separa um JSON Pointer (RFC 6901) em tokens já sem escape.
+func parsePointer(pointer string) ([]string, error) {
+    if pointer == "" {
+        return nil, nil
+    }
+    if !strings.HasPrefix(pointer, "/") {
+        return nil, fmt.Errorf("%w: pointer %q must start with /", ErrInvalidPatch, pointer)
+    }
+    tokens := strings.Split(pointer[1:], "/")
+    for i, t := range tokens {
+        tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+    }
+    return tokens, nil
+}
+
+func isProperPrefix(prefix, path []string) bool {
+    if len(prefix) >= len(path) {
+        return false
+    }
+    for i := range prefix {
+        if prefix[i] != path[i] {
+            return false
+        }
+    }
+    return true
+}
+
+func get(doc any, path []string) (any, error) {
+    node := doc
+    for _, token := range path {
+        switch n := node.(type) {
+        case map[string]any:
+            child, ok := n[token]
+            if !ok {
+                return nil, ErrPathNotFound
+            }
+            node = child
+        case []any:
+            i, err := arrayIndex(token, len(n)-1)
+            if err != nil {
+                return nil, err
+            }
+            node = n[i]
+        default:
+            return nil, ErrPathNotFound
+        }
+    }
+    return node, nil
+}
+
+// update desce até o pai do último token e aplica fn nele. Devolve o nó
+// atualizado, porque inserir ou remover de um array gera um novo slice.
+func update(node any, path []string, fn func(parent any, token string) (any, error)) (any, error) {
+    if len(path) == 1 {
+        return fn(node, path[0])
+    }
+    switch n := node.(type) {
+    case map[string]any:
+        child, ok := n[path[0]]
+        if !ok {
+            return nil, ErrPathNotFound
+        }
+        updated, err := update(child, path[1:], fn)
+        if err != nil {
+            return nil, err
+        }
+        n[path[0]] = updated
+        return n, nil
+    case []any:
+        i, err := arrayIndex(path[0], len(n)-1)
+        if err != nil {
+            return nil, err
+        }
+        updated, err := update(n[i], path[1:], fn)
+        if err != nil {
+            return nil, err
+        }
+        n[i] = updated
+        return n, nil
+    default:
+        return nil, ErrPathNotFound
+    }
+}
+
+func add(doc any, path []string, value any) (any, error) {
+    if len(path) == 0 {
+        return value, nil
+    }
+    return update(doc, path, func(parent any, token string) (any, error) {
+        switch p := parent.(type) {
+        case map[string]any:
+            p[token] = value
+            return p, nil
+        case []any:
+            if token == "-" {
+                return append(p, value), nil
+            }
+            i, err := arrayIndex(token, len(p))
+            if err != nil {
+                return nil, err
+            }
+            p = append(p, nil)
+            copy(p[i+1:], p[i:])
+            p[i] = value
+            return p, nil
+        default:
+            return nil, ErrPathNotFound
+        }
+    })
+}
+
+func replace(doc any, path []string, value any) (any, error) {
+    if len(path) == 0 {
+        return value, nil
+    }
+    return update(doc, path, func(parent any, token string) (any, error) {
+        switch p := parent.(type) {
+        case map[string]any:
+            if _, ok := p[token]; !ok {
+                return nil, ErrPathNotFound
+            }
+            p[token] = value
+            return p, nil
+        case []any:
+            i, err := arrayIndex(token, len(p)-1)
+            if err != nil {
+                return nil, err
+            }
+            p[i] = value
+            return p, nil
+        default:
+            return nil, ErrPathNotFound
+        }
+    })
+}
+
+func remove(doc any, path []string) (any, any, error) {
+    if len(path) == 0 {
+        return nil, nil, fmt.Errorf("%w: cannot remove the root", ErrInvalidPatch)
+    }
+    var removed any
+    doc, err := update(doc, path, func(parent any, token string) (any, error) {
+        switch p := parent.(type) {
+        case map[string]any:
+            value, ok := p[token]
+            if !ok {
+                return nil, ErrPathNotFound
+            }
+            removed = value
+            delete(p, token)
+            return p, nil
+        case []any:
+            i, err := arrayIndex(token, len(p)-1)
+            if err != nil {
+                return nil, err
+            }
+            removed = p[i]
+            return append(p[:i], p[i+1:]...), nil
+        default:
+            return nil, ErrPathNotFound
+        }
+    })
+    return doc, removed, err
+}
+
+// arrayIndex valida um índice de array: só dígitos, sem zeros à esquerda e
+// no máximo max.
+func arrayIndex(token string, max int) (int, error) {
+    if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+        return 0, fmt.Errorf("%w: invalid array index %q", ErrPathNotFound, token)
+    }
+    i, err := strconv.Atoi(token)
+    if err != nil || i > max {
+        return 0, fmt.Errorf("%w: array index %s out of range", ErrPathNotFound, token)
+    }
+    return i, nil
+}
+
+func deepCopy(v any) (any, error) {
+    data, err := json.Marshal(v)
+    if err != nil {
+        return nil, err
+    }
+    return decode(data)
+}
+
+// equal compara dois valores JSON; números valem pelo valor numérico, então
+// 1 e 1.0 são iguais.
+func equal(a, b any) bool {
+    switch x := a.(type) {
+    case json.Number:
+        y, ok := b.(json.Number)
+        if !ok {
+            return false
+        }
+        fx, okx := new(big.Float).SetString(x.String())
+        fy, oky := new(big.Float).SetString(y.String())
+        return okx && oky && fx.Cmp(fy) == 0
+    case map[string]any:
+        y, ok := b.(map[string]any)
+        if !ok || len(x) != len(y) {
+            return false
+        }
+        for key, value := range x {
+            other, ok := y[key]
+            if !ok || !equal(value, other) {
+                return false
+            }
+        }
+        return true
+    case []any:
+        y, ok := b.([]any)
+        if !ok || len(x) != len(y) {
+            return false
+        }
+        for i := range x {
+            if !equal(x[i], y[i]) {
+                return false
+            }
+        }
+        return true
+    default:
+        return reflect.DeepEqual(a, b)
+    }
+}
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Format string
+
+const (
+    FormatJSON Format = "json"
+    FormatText Format = "text"
+)
+
+// New cria o logger da aplicação. Níveis aceitos: debug, info, warn e error;
+// qualquer outro valor cai em info.
+func New(w io.Writer, level string, format Format) *slog.Logger {
+    opts := &slog.HandlerOptions{Level: ParseLevel(level)}
+    
+    var handler slog.Handler
+    if format == FormatText {
+        handler = slog.NewTextHandler(w, opts)
+    } else {
+        handler = slog.NewJSONHandler(w, opts)
+    }
+    
+    return slog.New(&contextHandler{Handler: handler})
+}
+
+func ParseLevel(level string) slog.Level {
+    switch strings.ToLower(level) {
+    case "debug":
+        return slog.LevelDebug
+    case "warn", "warning":
+        return slog.LevelWarn
+    case "error":
+        return slog.LevelError
+    default:
+        return slog.LevelInfo
+    }
+}
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+    return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+    requestID, _ := ctx.Value(requestIDKey{}).(string)
+    return requestID
+}
+
+// contextHandler anexa request_id e trace_id a todo registro feito com um
+// contexto de requisição (InfoContext, ErrorContext...).
+type contextHandler struct {
+    slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+    if requestID := RequestIDFromContext(ctx); requestID != "" {
+        record.AddAttrs(slog.String("request_id", requestID))
+    }
+    if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+        record.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()))
+    }
+    return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+    return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,77 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogMailer só registra a mensagem no log. Serve para desenvolvimento local.
+// Por padrão registra apenas destinatário e assunto: o corpo traz links com
+// tokens de uso único e só vai para o log (em DEBUG) com WithLogBody.
+type LogMailer struct {
+    logger  *slog.Logger
+    logBody bool
+}
+
+type LogMailerOption func(*LogMailer)
+
+// WithLogBody registra também o corpo, para que o link de verificação
+// apareça no terminal. Não deve ser usado fora de desenvolvimento.
+func WithLogBody() LogMailerOption {
+    return func(m *LogMailer) {
+        m.logBody = true
+    }
+}
+
+func NewLogMailer(logger *slog.Logger, opts ...LogMailerOption) *LogMailer {
+    m := &LogMailer{logger: logger}
+    for _, opt := range opts {
+        opt(m)
+    }
+    return m
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+    m.logger.InfoContext(ctx, "email sent", "to", msg.To, "subject", msg.Subject)
+    if m.logBody {
+        m.logger.DebugContext(ctx, "email body", "to", msg.To, "body", msg.Body)
+    }
+    return nil
+}
+
+// FileMailer anexa cada mensagem, já no formato RFC 5322, ao arquivo
+// informado. Útil em testes de ponta a ponta que precisam ler o link enviado.
+type FileMailer struct {
+    path  string
+    from  string
+    mutex sync.Mutex
+}
+
+func NewFileMailer(path, from string) *FileMailer {
+    return &FileMailer{path: path, from: from}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+    data, err := format(m.from, msg, time.Now())
+    if err != nil {
+        return fmt.Errorf("format email: %w", err)
+    }
+    
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    
+    f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+    if err != nil {
+        return fmt.Errorf("open mail file: %w", err)
+    }
+    defer f.Close()
+    
+    if _, err := f.Write(append(data, "\r\n"...)); err != nil {
+        return fmt.Errorf("write mail file: %w", err)
+    }
+    return nil
+}
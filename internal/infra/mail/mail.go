@@ -0,0 +1,83 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/quotedprintable"
+	"time"
+)
+
+// Message é um email de texto puro.
+type Message struct {
+    To      string
+    Subject string
+    Body    string
+}
+
+// Mailer entrega mensagens transacionais (verificação de email, reset de senha).
+type Mailer interface {
+    Send(ctx context.Context, msg Message) error
+}
+
+// Driver seleciona a implementação em NewMailer.
+type Driver string
+
+const (
+    DriverLog  Driver = "log"
+    DriverFile Driver = "file"
+    DriverSMTP Driver = "smtp"
+)
+
+type Config struct {
+    Driver   Driver
+    FilePath string
+    SMTP     SMTPConfig
+    // LogBody faz o driver log registrar o corpo (veja WithLogBody)
+    LogBody bool
+}
+
+func NewMailer(config Config, logger *slog.Logger) (Mailer, error) {
+    switch config.Driver {
+    case DriverLog, "":
+        if config.LogBody {
+            return NewLogMailer(logger, WithLogBody()), nil
+        }
+        return NewLogMailer(logger), nil
+    case DriverFile:
+        return NewFileMailer(config.FilePath, config.SMTP.From), nil
+    case DriverSMTP:
+        if config.SMTP.Host == "" {
+            return nil, errors.New("smtp mailer requires SMTP_HOST")
+        }
+        return NewSMTPMailer(config.SMTP), nil
+    default:
+        return nil, fmt.Errorf("unknown mail driver %q (expected log, file or smtp)", config.Driver)
+    }
+}
+
+// format monta a mensagem no formato RFC 5322, com o corpo em quoted-printable.
+func format(from string, msg Message, now time.Time) ([]byte, error) {
+    var buf bytes.Buffer
+    fmt.Fprintf(&buf, "From: %s\r\n", from)
+    fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+    fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+    fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+    buf.WriteString("MIME-Version: 1.0\r\n")
+    buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+    buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+    buf.WriteString("\r\n")
+    
+    w := quotedprintable.NewWriter(&buf)
+    if _, err := w.Write([]byte(msg.Body)); err != nil {
+        return nil, err
+    }
+    if err := w.Close(); err != nil {
+        return nil, err
+    }
+    buf.WriteString("\r\n")
+    return buf.Bytes(), nil
+}
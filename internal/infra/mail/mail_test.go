@@ -0,0 +1,110 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileMailer_WritesRFC5322Message(t *testing.T) {
+    // Arrange
+    path := filepath.Join(t.TempDir(), "mail.log")
+    mailer := NewFileMailer(path, "no-reply@localhost")
+    
+    // Act
+    err := mailer.Send(context.Background(), Message{
+        To:      "joao@email.com",
+        Subject: "Confirme seu email",
+        Body:    "Olá João,\n\nhttp://localhost:8080/verify?token=abc",
+    })
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Expected mail file, got %v", err)
+    }
+    content := string(data)
+    for _, want := range []string{
+        "From: no-reply@localhost\r\n",
+        "To: joao@email.com\r\n",
+        "Subject: Confirme seu email\r\n",
+        "Content-Transfer-Encoding: quoted-printable\r\n",
+        "Ol=C3=A1 Jo=C3=A3o",
+        "token=3Dabc",
+    } {
+        if !strings.Contains(content, want) {
+            t.Errorf("Expected message to contain %q, got:\n%s", want, content)
+        }
+    }
+}
+
+func TestLogMailer_KeepsBodyOutOfTheLogByDefault(t *testing.T) {
+    // Arrange
+    var buf bytes.Buffer
+    logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+    msg := Message{To: "joao@email.com", Subject: "Confirme seu email", Body: "http://localhost:8080/verify?token=abc"}
+    
+    // Act
+    _ = NewLogMailer(logger).Send(context.Background(), msg)
+    withoutBody := buf.String()
+    buf.Reset()
+    _ = NewLogMailer(logger, WithLogBody()).Send(context.Background(), msg)
+    withBody := buf.String()
+    
+    // Assert
+    if strings.Contains(withoutBody, "token=abc") || !strings.Contains(withoutBody, "joao@email.com") {
+        t.Errorf("Expected only recipient and subject by default, got:\n%s", withoutBody)
+    }
+    if !strings.Contains(withBody, "level=DEBUG") || !strings.Contains(withBody, "token=abc") {
+        t.Errorf("Expected the body at DEBUG with WithLogBody, got:\n%s", withBody)
+    }
+}
+
+func TestNewMailer_RejectsUnknownDriver(t *testing.T) {
+    if _, err := NewMailer(Config{Driver: "carrier-pigeon"}, nil); err == nil {
+        t.Error("Expected error for unknown driver")
+    }
+}
+
+func TestSMTPMailer_GivesUpOnSilentServer(t *testing.T) {
+    // Arrange: aceita a conexão e nunca manda o greeting
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    defer listener.Close()
+    go func() {
+        for {
+            conn, err := listener.Accept()
+            if err != nil {
+                return
+            }
+            defer conn.Close()
+        }
+    }()
+    host, port, _ := net.SplitHostPort(listener.Addr().String())
+    portNumber, _ := strconv.Atoi(port)
+    mailer := NewSMTPMailer(SMTPConfig{Host: host, Port: portNumber, From: "no-reply@localhost", Timeout: 100 * time.Millisecond})
+    
+    // Act: sem prazo no contexto, como no envio em segundo plano
+    start := time.Now()
+    err = mailer.Send(context.Background(), Message{To: "joao@email.com", Subject: "Teste", Body: "Olá"})
+    
+    // Assert
+    if err == nil {
+        t.Fatal("Expected an error from a silent server")
+    }
+    if elapsed := time.Since(start); elapsed > 2*time.Second {
+        t.Errorf("Expected Send to give up after the timeout, took %v", elapsed)
+    }
+}
@@ -0,0 +1,106 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// DefaultSendTimeout limita um envio inteiro pelo SMTP, da conexão ao QUIT.
+const DefaultSendTimeout = 30 * time.Second
+
+type SMTPConfig struct {
+    Host     string
+    Port     int
+    Username string
+    Password string
+    From     string
+    // Timeout vale para o envio inteiro; sem ele, DefaultSendTimeout
+    Timeout time.Duration
+}
+
+// SMTPMailer envia pelo servidor configurado, usando STARTTLS sempre que o
+// servidor oferecer. Autenticação só é tentada se houver usuário.
+type SMTPMailer struct {
+    config SMTPConfig
+}
+
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+    if config.Timeout <= 0 {
+        config.Timeout = DefaultSendTimeout
+    }
+    return &SMTPMailer{config: config}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+    data, err := format(m.config.From, msg, time.Now())
+    if err != nil {
+        return fmt.Errorf("format email: %w", err)
+    }
+    
+    // Quem chama pode não ter prazo (o envio do reset roda fora da
+    // requisição); sem um, um servidor mudo prenderia a goroutine para sempre.
+    ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+    defer cancel()
+    deadline, _ := ctx.Deadline()
+    
+    addr := net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+    dialer := net.Dialer{Timeout: m.config.Timeout}
+    conn, err := dialer.DialContext(ctx, "tcp", addr)
+    if err != nil {
+        return fmt.Errorf("connect to smtp server: %w", err)
+    }
+    if err := conn.SetDeadline(deadline); err != nil {
+        conn.Close()
+        return fmt.Errorf("set smtp deadline: %w", err)
+    }
+    
+    client, err := smtp.NewClient(conn, m.config.Host)
+    if err != nil {
+        conn.Close()
+        return fmt.Errorf("smtp handshake: %w", err)
+    }
+    defer client.Close()
+    
+    if ok, _ := client.Extension("STARTTLS"); ok {
+        if err := client.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+            return fmt.Errorf("smtp starttls: %w", err)
+        }
+    }
+    if m.config.Username != "" {
+        auth := smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+        if err := client.Auth(auth); err != nil {
+            return fmt.Errorf("smtp auth: %w", err)
+        }
+    }
+    
+    // MAIL FROM aceita só o endereço, sem o nome de exibição
+    from, err := netmail.ParseAddress(m.config.From)
+    if err != nil {
+        return fmt.Errorf("invalid sender address: %w", err)
+    }
+    if err := client.Mail(from.Address); err != nil {
+        return fmt.Errorf("smtp mail from: %w", err)
+    }
+    if err := client.Rcpt(msg.To); err != nil {
+        return fmt.Errorf("smtp rcpt to: %w", err)
+    }
+    
+    w, err := client.Data()
+    if err != nil {
+        return fmt.Errorf("smtp data: %w", err)
+    }
+    if _, err := w.Write(data); err != nil {
+        return fmt.Errorf("smtp write: %w", err)
+    }
+    if err := w.Close(); err != nil {
+        return fmt.Errorf("smtp data: %w", err)
+    }
+    
+    return client.Quit()
+}
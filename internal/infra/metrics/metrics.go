@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "user_api"
+
+type Metrics struct {
+    registry        *prometheus.Registry
+    httpRequests    *prometheus.CounterVec
+    httpDuration    *prometheus.HistogramVec
+    httpInFlight    prometheus.Gauge
+    dbQueryDuration *prometheus.HistogramVec
+}
+
+// New cria um registro próprio (em vez do global do client_golang) com as
+// métricas da API, do runtime Go e do processo.
+func New() *Metrics {
+    m := &Metrics{
+        registry: prometheus.NewRegistry(),
+        httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: namespace,
+            Subsystem: "http",
+            Name:      "requests_total",
+            Help:      "Total de requisições HTTP por método, rota e status.",
+        }, []string{"method", "route", "status"}),
+        httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+            Namespace: namespace,
+            Subsystem: "http",
+            Name:      "request_duration_seconds",
+            Help:      "Latência das requisições HTTP por método, rota e status.",
+            Buckets:   prometheus.DefBuckets,
+        }, []string{"method", "route", "status"}),
+        httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: namespace,
+            Subsystem: "http",
+            Name:      "requests_in_flight",
+            Help:      "Requisições HTTP em andamento.",
+        }),
+        dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+            Namespace: namespace,
+            Subsystem: "db",
+            Name:      "query_duration_seconds",
+            Help:      "Duração das chamadas ao repositório por método e resultado.",
+            Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+        }, []string{"repository", "method", "result"}),
+    }
+    
+    m.registry.MustRegister(
+        collectors.NewGoCollector(),
+        collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+        m.httpRequests,
+        m.httpDuration,
+        m.httpInFlight,
+        m.dbQueryDuration,
+    )
+    
+    return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+    return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) Register(collector prometheus.Collector) {
+    m.registry.MustRegister(collector)
+}
+
+func (m *Metrics) HTTPRequestStarted() {
+    m.httpInFlight.Inc()
+}
+
+func (m *Metrics) HTTPRequestFinished(method, route string, status int, duration time.Duration) {
+    m.httpInFlight.Dec()
+    
+    code := strconv.Itoa(status)
+    m.httpRequests.WithLabelValues(method, route, code).Inc()
+    m.httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// QueryObserver devolve a função usada pelo decorator de repositório
+// para registrar a duração de cada método.
+func (m *Metrics) QueryObserver(repository string) func(method string, duration time.Duration, err error) {
+    return func(method string, duration time.Duration, err error) {
+        result := "success"
+        if err != nil {
+            result = "error"
+        }
+        m.dbQueryDuration.WithLabelValues(repository, method, result).Observe(duration.Seconds())
+    }
+}
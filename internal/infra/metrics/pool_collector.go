@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector expõe o pgxpool.Stat() no momento de cada scrape.
+type PoolCollector struct {
+    pool *pgxpool.Pool
+    
+    acquiredConns     *prometheus.Desc
+    idleConns         *prometheus.Desc
+    totalConns        *prometheus.Desc
+    maxConns          *prometheus.Desc
+    acquireCount      *prometheus.Desc
+    acquireDuration   *prometheus.Desc
+    emptyAcquireCount *prometheus.Desc
+    canceledAcquires  *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+    desc := func(name, help string) *prometheus.Desc {
+        return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+    }
+    
+    return &PoolCollector{
+        pool:              pool,
+        acquiredConns:     desc("acquired_conns", "Conexões atualmente em uso."),
+        idleConns:         desc("idle_conns", "Conexões ociosas no pool."),
+        totalConns:        desc("total_conns", "Total de conexões abertas no pool."),
+        maxConns:          desc("max_conns", "Tamanho máximo do pool."),
+        acquireCount:      desc("acquires_total", "Total de conexões adquiridas do pool."),
+        acquireDuration:   desc("acquire_duration_seconds_total", "Tempo total gasto esperando por conexões."),
+        emptyAcquireCount: desc("empty_acquires_total", "Aquisições que precisaram esperar por uma conexão livre."),
+        canceledAcquires:  desc("canceled_acquires_total", "Aquisições canceladas pelo contexto."),
+    }
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+    ch <- c.acquiredConns
+    ch <- c.idleConns
+    ch <- c.totalConns
+    ch <- c.maxConns
+    ch <- c.acquireCount
+    ch <- c.acquireDuration
+    ch <- c.emptyAcquireCount
+    ch <- c.canceledAcquires
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+    stat := c.pool.Stat()
+    
+    ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+    ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+    ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+    ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+    ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+    ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+    ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+    ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
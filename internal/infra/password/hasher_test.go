@@ -0,0 +1,50 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHasher_RoundTrip(t *testing.T) {
+    for _, algorithm := range []Algorithm{Argon2id, Bcrypt} {
+        t.Run(string(algorithm), func(t *testing.T) {
+            // Arrange
+            hasher, err := NewHasher(algorithm)
+            if err != nil {
+                t.Fatalf("Expected no error, got %v", err)
+            }
+            
+            // Act
+            hash, err := hasher.Hash("S3nhaForte")
+            
+            // Assert
+            if err != nil {
+                t.Fatalf("Expected no error, got %v", err)
+            }
+            if strings.Contains(hash, "S3nhaForte") {
+                t.Fatal("Hash must not contain the password")
+            }
+            if ok, err := hasher.Verify(hash, "S3nhaForte"); err != nil || !ok {
+                t.Errorf("Expected password to match, got ok=%v err=%v", ok, err)
+            }
+            if ok, _ := hasher.Verify(hash, "Errada123"); ok {
+                t.Error("Expected wrong password to be rejected")
+            }
+        })
+    }
+}
+
+func TestHasher_VerifiesHashesFromOtherAlgorithm(t *testing.T) {
+    bcryptHasher, _ := NewHasher(Bcrypt)
+    hash, _ := bcryptHasher.Hash("S3nhaForte")
+    
+    if ok, err := DefaultHasher().Verify(hash, "S3nhaForte"); err != nil || !ok {
+        t.Errorf("Expected argon2id hasher to verify bcrypt hash, got ok=%v err=%v", ok, err)
+    }
+}
+
+func TestNewHasher_RejectsUnknownAlgorithm(t *testing.T) {
+    if _, err := NewHasher("md5"); err == nil {
+        t.Error("Expected error for unsupported algorithm")
+    }
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var ErrLimitExceeded = errors.New("rate limit exceeded")
+
+// LimitError informa quanto tempo falta para a janela atual acabar.
+type LimitError struct {
+    RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+    return fmt.Sprintf("rate limit exceeded, retry after %s", e.RetryAfter)
+}
+
+func (e *LimitError) Is(target error) bool {
+    return target == ErrLimitExceeded
+}
+
+type fixedWindow struct {
+    start time.Time
+    count int
+}
+
+// Limiter permite até limit chamadas por chave a cada janela fixa. O estado
+// fica em memória, então o limite vale por instância da API.
+type Limiter struct {
+    limit     int
+    window    time.Duration
+    windows   map[string]*fixedWindow
+    lastSweep time.Time
+    mutex     sync.Mutex
+    now       func() time.Time
+}
+
+func NewLimiter(limit int, window time.Duration) *Limiter {
+    return &Limiter{
+        limit:   limit,
+        window:  window,
+        windows: make(map[string]*fixedWindow),
+        now:     time.Now,
+    }
+}
+
+// Allow consome uma chamada da chave. Quando o limite estourou, devolve um
+// *LimitError com o tempo restante da janela.
+func (l *Limiter) Allow(key string) error {
+    l.mutex.Lock()
+    defer l.mutex.Unlock()
+    
+    now := l.now()
+    l.sweep(now)
+    
+    w, exists := l.windows[key]
+    if !exists || !now.Before(w.start.Add(l.window)) {
+        w = &fixedWindow{start: now}
+        l.windows[key] = w
+    }
+    
+    if w.count >= l.limit {
+        return &LimitError{RetryAfter: w.start.Add(l.window).Sub(now)}
+    }
+    w.count++
+    return nil
+}
+
+// sweep descarta janelas vencidas, no máximo uma vez por janela, para que
+// chaves que nunca voltam não fiquem na memória para sempre.
+func (l *Limiter) sweep(now time.Time) {
+    if now.Sub(l.lastSweep) < l.window {
+        return
+    }
+    for key, w := range l.windows {
+        if !now.Before(w.start.Add(l.window)) {
+            delete(l.windows, key)
+        }
+    }
+    l.lastSweep = now
+}
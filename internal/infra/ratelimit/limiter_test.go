@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+    // Arrange
+    now := time.Date(2024, 7, 8, 10, 0, 0, 0, time.UTC)
+    limiter := NewLimiter(2, time.Minute)
+    limiter.now = func() time.Time { return now }
+    
+    // Act & Assert
+    for i := 0; i < 2; i++ {
+        if err := limiter.Allow("joao@email.com"); err != nil {
+            t.Fatalf("Expected call %d to be allowed, got %v", i+1, err)
+        }
+    }
+    
+    err := limiter.Allow("joao@email.com")
+    var limitErr *LimitError
+    if !errors.As(err, &limitErr) || !errors.Is(err, ErrLimitExceeded) {
+        t.Fatalf("Expected LimitError, got %v", err)
+    }
+    if limitErr.RetryAfter != time.Minute {
+        t.Errorf("Expected retry after 1m, got %s", limitErr.RetryAfter)
+    }
+    
+    if err := limiter.Allow("maria@email.com"); err != nil {
+        t.Errorf("Expected other keys to be unaffected, got %v", err)
+    }
+    
+    now = now.Add(time.Minute)
+    if err := limiter.Allow("joao@email.com"); err != nil {
+        t.Errorf("Expected a new window to allow calls, got %v", err)
+    }
+}
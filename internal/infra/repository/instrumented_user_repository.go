@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+// QueryObserver recebe a duração e o resultado de cada chamada ao repositório.
+type QueryObserver func(method string, duration time.Duration, err error)
+
+// InstrumentedUserRepository é um decorator que mede qualquer UserRepository.
+type InstrumentedUserRepository struct {
+    next    UserRepository
+    observe QueryObserver
+}
+
+func NewInstrumentedUserRepository(next UserRepository, observe QueryObserver) UserRepository {
+    return &InstrumentedUserRepository{next: next, observe: observe}
+}
+
+func (r *InstrumentedUserRepository) Save(ctx context.Context, u *entity.User) (err error) {
+    defer r.track("Save", time.Now(), &err)
+    return r.next.Save(ctx, u)
+}
+
+func (r *InstrumentedUserRepository) FindByID(ctx context.Context, id string) (_ *entity.User, err error) {
+    defer r.track("FindByID", time.Now(), &err)
+    return r.next.FindByID(ctx, id)
+}
+
+func (r *InstrumentedUserRepository) FindByEmail(ctx context.Context, normalizedEmail string) (_ *entity.User, err error) {
+    defer r.track("FindByEmail", time.Now(), &err)
+    return r.next.FindByEmail(ctx, normalizedEmail)
+}
+
+func (r *InstrumentedUserRepository) FindAll(ctx context.Context, opts ListOptions) (_ []*entity.User, err error) {
+    defer r.track("FindAll", time.Now(), &err)
+    return r.next.FindAll(ctx, opts)
+}
+
+func (r *InstrumentedUserRepository) FindDeletedByID(ctx context.Context, id string) (_ *entity.User, err error) {
+    defer r.track("FindDeletedByID", time.Now(), &err)
+    return r.next.FindDeletedByID(ctx, id)
+}
+
+func (r *InstrumentedUserRepository) Delete(ctx context.Context, id string) (err error) {
+    defer r.track("Delete", time.Now(), &err)
+    return r.next.Delete(ctx, id)
+}
+
+func (r *InstrumentedUserRepository) PurgeDeleted(ctx context.Context, before time.Time) (_ int64, err error) {
+    defer r.track("PurgeDeleted", time.Now(), &err)
+    return r.next.PurgeDeleted(ctx, before)
+}
+
+func (r *InstrumentedUserRepository) track(method string, start time.Time, err *error) {
+    r.observe(method, time.Since(start), *err)
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+    ErrInvalidCursor = errors.New("invalid cursor")
+    // ErrCursorSortMismatch indica um cursor emitido para outra ordenação:
+    // usado com a ordem trocada, ele pularia ou repetiria itens
+    ErrCursorSortMismatch = errors.New("cursor was issued for a different sort")
+)
+
+type SortDirection string
+
+const (
+    SortAsc  SortDirection = "asc"
+    SortDesc SortDirection = "desc"
+)
+
+// ListOptions descreve uma página de usuários. A paginação é keyset sobre
+// (created_at, id), então o cursor é sempre a posição do último item visto.
+type ListOptions struct {
+    Limit         int
+    After         *Cursor
+    Sort          SortDirection
+    NamePrefix    string
+    EmailPrefix   string
+    CreatedAfter  time.Time
+    CreatedBefore time.Time
+}
+
+// Cursor é a posição do último item visto e a ordenação em que a página
+// foi gerada, para que não seja usado com outra.
+type Cursor struct {
+    CreatedAt time.Time     `json:"c"`
+    ID        string        `json:"i"`
+    Sort      SortDirection `json:"s"`
+}
+
+// Encode gera a representação opaca do cursor enviada ao cliente.
+func (c Cursor) Encode() string {
+    data, _ := json.Marshal(c)
+    return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+    data, err := base64.RawURLEncoding.DecodeString(s)
+    if err != nil {
+        return nil, ErrInvalidCursor
+    }
+
+    var c Cursor
+    if err := json.Unmarshal(data, &c); err != nil || !isUUID(c.ID) || c.CreatedAt.IsZero() {
+        return nil, ErrInvalidCursor
+    }
+    if c.Sort != SortAsc && c.Sort != SortDesc {
+        return nil, ErrInvalidCursor
+    }
+    return &c, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDecodeCursor(t *testing.T) {
+    createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+    valid := Cursor{CreatedAt: createdAt, ID: uuid.NewString(), Sort: SortAsc}
+    
+    decoded, err := DecodeCursor(valid.Encode())
+    if err != nil {
+        t.Fatalf("Expected the encoded cursor to decode, got %v", err)
+    }
+    if !decoded.CreatedAt.Equal(valid.CreatedAt) || decoded.ID != valid.ID || decoded.Sort != valid.Sort {
+        t.Errorf("Expected %+v after the round trip, got %+v", valid, decoded)
+    }
+    
+    cases := []struct {
+        name   string
+        cursor string
+    }{
+        {"not base64", "not a cursor!"},
+        {"not json", base64.RawURLEncoding.EncodeToString([]byte("abc"))},
+        {"forged id", Cursor{CreatedAt: createdAt, ID: "' OR 1=1 --", Sort: SortAsc}.Encode()},
+        {"missing id", Cursor{CreatedAt: createdAt, Sort: SortAsc}.Encode()},
+        {"missing created_at", Cursor{ID: uuid.NewString(), Sort: SortAsc}.Encode()},
+        {"unknown sort", Cursor{CreatedAt: createdAt, ID: uuid.NewString(), Sort: "sideways"}.Encode()},
+    }
+    
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            if _, err := DecodeCursor(tc.cursor); !errors.Is(err, ErrInvalidCursor) {
+                t.Errorf("Expected ErrInvalidCursor, got %v", err)
+            }
+        })
+    }
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+// InMemoryOneTimeTokenRepository nunca altera um token guardado: cada
+// escrita guarda uma cópia nova, o que permite ao InMemoryTxManager saber
+// se o registro mudou depois da transação.
+type InMemoryOneTimeTokenRepository struct {
+    tokens map[string]*entity.OneTimeToken
+    mutex  sync.Mutex
+}
+
+func NewInMemoryOneTimeTokenRepository() OneTimeTokenRepository {
+    return &InMemoryOneTimeTokenRepository{
+        tokens: make(map[string]*entity.OneTimeToken),
+    }
+}
+
+func (r *InMemoryOneTimeTokenRepository) Save(ctx context.Context, t *entity.OneTimeToken) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    r.save(t, nil)
+    return nil
+}
+
+func (r *InMemoryOneTimeTokenRepository) Consume(ctx context.Context, purpose entity.TokenPurpose, tokenHash string, now time.Time) (*entity.OneTimeToken, error) {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    return r.consume(purpose, tokenHash, now, nil), nil
+}
+
+func (r *InMemoryOneTimeTokenRepository) InvalidateForUser(ctx context.Context, userID string, purpose entity.TokenPurpose, now time.Time) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    r.invalidateForUser(userID, purpose, now, nil)
+    return nil
+}
+
+// save, consume e invalidateForUser são as escritas sem o lock; undo (que
+// pode ser nil) registra cada troca para o rollback de uma transação.
+func (r *InMemoryOneTimeTokenRepository) save(t *entity.OneTimeToken, undo *undoLog[entity.OneTimeToken]) {
+    stored := *t
+    r.replace(t.TokenHash, &stored, undo)
+}
+
+func (r *InMemoryOneTimeTokenRepository) consume(purpose entity.TokenPurpose, tokenHash string, now time.Time, undo *undoLog[entity.OneTimeToken]) *entity.OneTimeToken {
+    t, exists := r.tokens[tokenHash]
+    if !exists || t.Purpose != purpose || !t.Usable(now) {
+        return nil
+    }
+    used := *t
+    used.UsedAt = &now
+    r.replace(tokenHash, &used, undo)
+    
+    consumed := used
+    return &consumed
+}
+
+func (r *InMemoryOneTimeTokenRepository) invalidateForUser(userID string, purpose entity.TokenPurpose, now time.Time, undo *undoLog[entity.OneTimeToken]) {
+    for hash, t := range r.tokens {
+        if t.UserID == userID && t.Purpose == purpose && t.UsedAt == nil {
+            used := *t
+            used.UsedAt = &now
+            r.replace(hash, &used, undo)
+        }
+    }
+}
+
+// replace troca o token guardado em tokenHash (nil remove).
+func (r *InMemoryOneTimeTokenRepository) replace(tokenHash string, t *entity.OneTimeToken, undo *undoLog[entity.OneTimeToken]) {
+    undo.track(tokenHash, r.tokens[tokenHash], t)
+    if t == nil {
+        delete(r.tokens, tokenHash)
+        return
+    }
+    r.tokens[tokenHash] = t
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+type InMemoryOutboxRepository struct {
+    messages map[string]*entity.OutboxMessage
+    mutex    sync.Mutex
+}
+
+func NewInMemoryOutboxRepository() OutboxRepository {
+    return &InMemoryOutboxRepository{
+        messages: make(map[string]*entity.OutboxMessage),
+    }
+}
+
+func (r *InMemoryOutboxRepository) Add(ctx context.Context, messages ...*entity.OutboxMessage) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    for _, m := range messages {
+        r.messages[m.ID] = copyOutboxMessage(m)
+    }
+    return nil
+}
+
+func (r *InMemoryOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxMessage, error) {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    var due []*entity.OutboxMessage
+    for _, m := range r.messages {
+        if m.Status == entity.OutboxPending && !m.NextAttemptAt.After(now) {
+            due = append(due, m)
+        }
+    }
+    sortOutboxMessages(due)
+    if limit > 0 && len(due) > limit {
+        due = due[:limit]
+    }
+
+    claimed := make([]*entity.OutboxMessage, 0, len(due))
+    for _, m := range due {
+        m.NextAttemptAt = now.Add(lease)
+        claimed = append(claimed, copyOutboxMessage(m))
+    }
+    return claimed, nil
+}
+
+func (r *InMemoryOutboxRepository) Update(ctx context.Context, message *entity.OutboxMessage) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    if _, exists := r.messages[message.ID]; exists {
+        r.messages[message.ID] = copyOutboxMessage(message)
+    }
+    return nil
+}
+
+// remove apaga as mensagens informadas; usado no rollback do InMemoryTxManager.
+func (r *InMemoryOutboxRepository) remove(ids []string) {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    for _, id := range ids {
+        delete(r.messages, id)
+    }
+}
+
+func copyOutboxMessage(m *entity.OutboxMessage) *entity.OutboxMessage {
+    c := *m
+    return &c
+}
+
+// sortOutboxMessages ordena pela criação, como o ORDER BY do Postgres.
+func sortOutboxMessages(messages []*entity.OutboxMessage) {
+    sort.Slice(messages, func(i, j int) bool {
+        if !messages[i].CreatedAt.Equal(messages[j].CreatedAt) {
+            return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+        }
+        return messages[i].ID < messages[j].ID
+    })
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+// InMemoryRefreshTokenRepository nunca altera um token guardado: cada
+// escrita guarda uma cópia nova, o que permite ao InMemoryTxManager saber
+// se o registro mudou depois da transação.
+type InMemoryRefreshTokenRepository struct {
+    tokens map[string]*entity.RefreshToken
+    mutex  sync.RWMutex
+}
+
+func NewInMemoryRefreshTokenRepository() RefreshTokenRepository {
+    return &InMemoryRefreshTokenRepository{
+        tokens: make(map[string]*entity.RefreshToken),
+    }
+}
+
+func (r *InMemoryRefreshTokenRepository) Save(ctx context.Context, t *entity.RefreshToken) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    r.save(t, nil)
+    return nil
+}
+
+func (r *InMemoryRefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+    
+    t, exists := r.tokens[tokenHash]
+    if !exists {
+        return nil, nil
+    }
+    found := *t
+    return &found, nil
+}
+
+func (r *InMemoryRefreshTokenRepository) Consume(ctx context.Context, tokenHash string, now time.Time) (*entity.RefreshToken, error) {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    return r.consume(tokenHash, now, nil), nil
+}
+
+func (r *InMemoryRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string, at time.Time) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    r.revokeAllForUser(userID, at, nil)
+    return nil
+}
+
+// save, consume e revokeAllForUser são as escritas sem o lock; undo (que
+// pode ser nil) registra cada troca para o rollback de uma transação.
+func (r *InMemoryRefreshTokenRepository) save(t *entity.RefreshToken, undo *undoLog[entity.RefreshToken]) {
+    stored := *t
+    r.replace(t.TokenHash, &stored, undo)
+}
+
+func (r *InMemoryRefreshTokenRepository) consume(tokenHash string, now time.Time, undo *undoLog[entity.RefreshToken]) *entity.RefreshToken {
+    t, exists := r.tokens[tokenHash]
+    if !exists || t.Revoked() || t.Expired(now) {
+        return nil
+    }
+    revoked := *t
+    revoked.Revoke(now)
+    r.replace(tokenHash, &revoked, undo)
+    
+    consumed := revoked
+    return &consumed
+}
+
+func (r *InMemoryRefreshTokenRepository) revokeAllForUser(userID string, at time.Time, undo *undoLog[entity.RefreshToken]) {
+    for hash, t := range r.tokens {
+        if t.UserID == userID && !t.Revoked() {
+            revoked := *t
+            revoked.Revoke(at)
+            r.replace(hash, &revoked, undo)
+        }
+    }
+}
+
+// replace troca o token guardado em tokenHash (nil remove).
+func (r *InMemoryRefreshTokenRepository) replace(tokenHash string, t *entity.RefreshToken, undo *undoLog[entity.RefreshToken]) {
+    undo.track(tokenHash, r.tokens[tokenHash], t)
+    if t == nil {
+        delete(r.tokens, tokenHash)
+        return
+    }
+    r.tokens[tokenHash] = t
+}
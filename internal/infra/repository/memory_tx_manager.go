@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+// InMemoryTxManager faz para o repositório em memória o papel da transação:
+// as unidades de trabalho rodam uma de cada vez e, se falharem, cada
+// registro que gravaram volta ao estado em que estava antes delas. Não é
+// reentrante: chamar WithinTx dentro de fn trava.
+type InMemoryTxManager struct {
+    users         *InMemoryUserRepository
+    outbox        *InMemoryOutboxRepository
+    refreshTokens *InMemoryRefreshTokenRepository
+    oneTimeTokens *InMemoryOneTimeTokenRepository
+    decorate      func(Repositories) Repositories
+    mutex         sync.Mutex
+}
+
+// NewInMemoryTxManager exige Users e Outbox. RefreshTokens e OneTimeTokens
+// são opcionais: sem eles, as unidades de trabalho os recebem nil.
+func NewInMemoryTxManager(repos Repositories, opts ...TxOption) TxManager {
+    m := &InMemoryTxManager{decorate: newTxOptions(opts).decorate}
+    var ok bool
+    if m.users, ok = repos.Users.(*InMemoryUserRepository); !ok {
+        panic("in-memory transactions require the in-memory user repository")
+    }
+    if m.outbox, ok = repos.Outbox.(*InMemoryOutboxRepository); !ok {
+        panic("in-memory transactions require the in-memory outbox repository")
+    }
+    if repos.RefreshTokens != nil {
+        if m.refreshTokens, ok = repos.RefreshTokens.(*InMemoryRefreshTokenRepository); !ok {
+            panic("in-memory transactions require the in-memory refresh token repository")
+        }
+    }
+    if repos.OneTimeTokens != nil {
+        if m.oneTimeTokens, ok = repos.OneTimeTokens.(*InMemoryOneTimeTokenRepository); !ok {
+            panic("in-memory transactions require the in-memory one-time token repository")
+        }
+    }
+    return m
+}
+
+func (m *InMemoryTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) (err error) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+
+    users := &inMemoryUserTx{InMemoryUserRepository: m.users, undo: newUndoLog[entity.User]()}
+    outbox := &inMemoryOutboxTx{InMemoryOutboxRepository: m.outbox}
+    repos := Repositories{Users: users, Outbox: outbox}
+    rollbacks := []func(){users.rollback, func() { outbox.remove(outbox.added) }}
+    if m.refreshTokens != nil {
+        refreshTokens := &inMemoryRefreshTokenTx{InMemoryRefreshTokenRepository: m.refreshTokens, undo: newUndoLog[entity.RefreshToken]()}
+        repos.RefreshTokens = refreshTokens
+        rollbacks = append(rollbacks, refreshTokens.rollback)
+    }
+    if m.oneTimeTokens != nil {
+        oneTimeTokens := &inMemoryOneTimeTokenTx{InMemoryOneTimeTokenRepository: m.oneTimeTokens, undo: newUndoLog[entity.OneTimeToken]()}
+        repos.OneTimeTokens = oneTimeTokens
+        rollbacks = append(rollbacks, oneTimeTokens.rollback)
+    }
+
+    committed := false
+    defer func() {
+        if !committed {
+            for _, rollback := range rollbacks {
+                rollback()
+            }
+        }
+    }()
+
+    if err := fn(ctx, m.decorate(repos)); err != nil {
+        return err
+    }
+    committed = true
+    return nil
+}
+
+// undoLog guarda, para cada chave gravada pela unidade de trabalho, o valor
+// de antes da primeira escrita e o ponteiro deixado pela última. Como os
+// repositórios em memória sempre guardam uma cópia nova a cada escrita, o
+// ponteiro serve de versão: se ele mudou, alguém fora da unidade de trabalho
+// gravou depois, e o rollback não pode desfazer essa escrita.
+type undoLog[T any] struct {
+    before map[string]*T
+    after  map[string]*T
+}
+
+func newUndoLog[T any]() *undoLog[T] {
+    return &undoLog[T]{before: make(map[string]*T), after: make(map[string]*T)}
+}
+
+// track registra que a chave passou de before para after (nil: removida).
+// Um undoLog nil não registra nada: é o caso das escritas fora de transação.
+func (l *undoLog[T]) track(key string, before, after *T) {
+    if l == nil {
+        return
+    }
+    if _, seen := l.before[key]; !seen {
+        l.before[key] = before
+    }
+    l.after[key] = after
+}
+
+// undo chama restore com o valor original de cada chave que ainda guarda o
+// que a unidade de trabalho gravou. current lê o valor guardado agora.
+func (l *undoLog[T]) undo(current func(key string) *T, restore func(key string, value *T)) {
+    for key, before := range l.before {
+        if current(key) == l.after[key] {
+            restore(key, before)
+        }
+    }
+}
+
+// inMemoryUserTx lê direto do repositório e registra no undoLog cada
+// escrita que faz.
+type inMemoryUserTx struct {
+    *InMemoryUserRepository
+    undo *undoLog[entity.User]
+}
+
+func (t *inMemoryUserTx) Save(ctx context.Context, u *entity.User) error {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    before := t.users[u.ID]
+    if err := t.save(u); err != nil {
+        return err
+    }
+    t.undo.track(u.ID, before, t.users[u.ID])
+    return nil
+}
+
+func (t *inMemoryUserTx) Delete(ctx context.Context, id string) error {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    before, exists := t.users[id]
+    if !exists {
+        return entity.ErrUserNotFound
+    }
+    t.put(id, nil)
+    t.undo.track(id, before, nil)
+    return nil
+}
+
+func (t *inMemoryUserTx) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    var purged int64
+    for id, u := range t.users {
+        if u.IsDeleted() && u.DeletedAt.Before(before) {
+            t.put(id, nil)
+            t.undo.track(id, u, nil)
+            purged++
+        }
+    }
+    return purged, nil
+}
+
+func (t *inMemoryUserTx) rollback() {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    t.undo.undo(func(id string) *entity.User { return t.users[id] }, t.put)
+}
+
+// inMemoryRefreshTokenTx e inMemoryOneTimeTokenTx fazem as escritas dos
+// repositórios de tokens registrando cada troca no undoLog.
+type inMemoryRefreshTokenTx struct {
+    *InMemoryRefreshTokenRepository
+    undo *undoLog[entity.RefreshToken]
+}
+
+func (t *inMemoryRefreshTokenTx) Save(ctx context.Context, token *entity.RefreshToken) error {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    t.save(token, t.undo)
+    return nil
+}
+
+func (t *inMemoryRefreshTokenTx) Consume(ctx context.Context, tokenHash string, now time.Time) (*entity.RefreshToken, error) {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    return t.consume(tokenHash, now, t.undo), nil
+}
+
+func (t *inMemoryRefreshTokenTx) RevokeAllForUser(ctx context.Context, userID string, at time.Time) error {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    t.revokeAllForUser(userID, at, t.undo)
+    return nil
+}
+
+func (t *inMemoryRefreshTokenTx) rollback() {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    t.undo.undo(func(hash string) *entity.RefreshToken { return t.tokens[hash] },
+        func(hash string, token *entity.RefreshToken) { t.replace(hash, token, nil) })
+}
+
+type inMemoryOneTimeTokenTx struct {
+    *InMemoryOneTimeTokenRepository
+    undo *undoLog[entity.OneTimeToken]
+}
+
+func (t *inMemoryOneTimeTokenTx) Save(ctx context.Context, token *entity.OneTimeToken) error {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    t.save(token, t.undo)
+    return nil
+}
+
+func (t *inMemoryOneTimeTokenTx) Consume(ctx context.Context, purpose entity.TokenPurpose, tokenHash string, now time.Time) (*entity.OneTimeToken, error) {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    return t.consume(purpose, tokenHash, now, t.undo), nil
+}
+
+func (t *inMemoryOneTimeTokenTx) InvalidateForUser(ctx context.Context, userID string, purpose entity.TokenPurpose, now time.Time) error {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    t.invalidateForUser(userID, purpose, now, t.undo)
+    return nil
+}
+
+func (t *inMemoryOneTimeTokenTx) rollback() {
+    t.mutex.Lock()
+    defer t.mutex.Unlock()
+
+    t.undo.undo(func(hash string) *entity.OneTimeToken { return t.tokens[hash] },
+        func(hash string, token *entity.OneTimeToken) { t.replace(hash, token, nil) })
+}
+
+// inMemoryOutboxTx lembra as mensagens adicionadas, que o rollback apaga.
+type inMemoryOutboxTx struct {
+    *InMemoryOutboxRepository
+    added []string
+}
+
+func (t *inMemoryOutboxTx) Add(ctx context.Context, messages ...*entity.OutboxMessage) error {
+    for _, m := range messages {
+        t.added = append(t.added, m.ID)
+    }
+    return t.InMemoryOutboxRepository.Add(ctx, messages...)
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+func TestInMemoryTxManager_RollbackKeepsWritesMadeOutsideTheTx(t *testing.T) {
+    // Arrange
+    repo := NewInMemoryUserRepository()
+    tx := NewInMemoryTxManager(Repositories{Users: repo, Outbox: NewInMemoryOutboxRepository()})
+    ctx := context.Background()
+    
+    joaoEmail, _ := entity.DefaultEmailPolicy.Parse("joao@email.com")
+    joao, _ := entity.NewUser("João Silva", joaoEmail)
+    mariaEmail, _ := entity.DefaultEmailPolicy.Parse("maria@email.com")
+    maria, _ := entity.NewUser("Maria Santos", mariaEmail)
+    _ = repo.Save(ctx, joao)
+    _ = repo.Save(ctx, maria)
+    failure := errors.New("falha no meio da transação")
+    
+    // Act: a transação grava os dois, e por fora alguém grava joao depois dela
+    err := tx.WithinTx(ctx, func(ctx context.Context, repos Repositories) error {
+        for _, id := range []string{joao.ID, maria.ID} {
+            user, _ := repos.Users.FindByID(ctx, id)
+            _ = user.UpdateName("Nome da Transação")
+            if err := repos.Users.Save(ctx, user); err != nil {
+                return err
+            }
+        }
+    
+        outside, _ := repo.FindByID(ctx, joao.ID)
+        _ = outside.UpdateName("João Santos")
+        if err := repo.Save(ctx, outside); err != nil {
+            return err
+        }
+        return failure
+    })
+    
+    // Assert
+    if !errors.Is(err, failure) {
+        t.Fatalf("Expected the error returned by fn, got %v", err)
+    }
+    if stored, _ := repo.FindByID(ctx, joao.ID); stored.Name != "João Santos" || stored.Version != 3 {
+        t.Errorf("Expected the outside write to survive rollback, got %+v", stored)
+    }
+    if stored, _ := repo.FindByID(ctx, maria.ID); stored.Name != "Maria Santos" || stored.Version != 1 {
+        t.Errorf("Expected maria back at version 1, got %+v", stored)
+    }
+}
+
+func TestInMemoryTxManager_RollsBackOnError(t *testing.T) {
+    // Arrange
+    repo := NewUserRepository(InMemory, nil)
+    outbox := NewInMemoryOutboxRepository()
+    tx := NewInMemoryTxManager(Repositories{Users: repo, Outbox: outbox})
+    ctx := context.Background()
+    
+    joaoEmail, _ := entity.DefaultEmailPolicy.Parse("joao@email.com")
+    joao, _ := entity.NewUser("João Silva", joaoEmail)
+    mariaEmail, _ := entity.DefaultEmailPolicy.Parse("maria@email.com")
+    maria, _ := entity.NewUser("Maria Santos", mariaEmail)
+    _ = repo.Save(ctx, joao)
+    _ = repo.Save(ctx, maria)
+    failure := errors.New("falha no meio da transação")
+    
+    // Act
+    err := tx.WithinTx(ctx, func(ctx context.Context, repos Repositories) error {
+        user, _ := repos.Users.FindByID(ctx, joao.ID)
+        newEmail, _ := entity.DefaultEmailPolicy.Parse("joao.santos@email.com")
+        _ = user.UpdateEmail(newEmail)
+        if err := repos.Users.Save(ctx, user); err != nil {
+            return err
+        }
+        if err := repos.Users.Delete(ctx, maria.ID); err != nil {
+            return err
+        }
+        anaEmail, _ := entity.DefaultEmailPolicy.Parse("ana@email.com")
+        ana, _ := entity.NewUser("Ana Lima", anaEmail)
+        if err := repos.Users.Save(ctx, ana); err != nil {
+            return err
+        }
+        message, _ := entity.NewOutboxMessage(ana.Events()[0])
+        if err := repos.Outbox.Add(ctx, message); err != nil {
+            return err
+        }
+        return failure
+    })
+    
+    // Assert
+    if !errors.Is(err, failure) {
+        t.Fatalf("Expected the error returned by fn, got %v", err)
+    }
+    if stored, _ := repo.FindByEmail(ctx, "joao@email.com"); stored == nil || stored.ID != joao.ID || stored.Version != 1 {
+        t.Errorf("Expected joao back at version 1 under his old email, got %+v", stored)
+    }
+    if stored, _ := repo.FindByEmail(ctx, "joao.santos@email.com"); stored != nil {
+        t.Error("Expected the new email to be free after rollback")
+    }
+    if stored, _ := repo.FindByID(ctx, maria.ID); stored == nil {
+        t.Error("Expected maria to be restored after rollback")
+    }
+    if stored, _ := repo.FindByEmail(ctx, "ana@email.com"); stored != nil {
+        t.Error("Expected ana not to exist after rollback")
+    }
+    if pending, _ := outbox.ClaimDue(ctx, time.Now(), time.Minute, 10); len(pending) != 0 {
+        t.Errorf("Expected no outbox messages after rollback, got %d", len(pending))
+    }
+}
+
+func TestInMemoryTxManager_CommitsOnSuccess(t *testing.T) {
+    // Arrange
+    repo := NewUserRepository(InMemory, nil)
+    outbox := NewInMemoryOutboxRepository()
+    tx := NewInMemoryTxManager(Repositories{Users: repo, Outbox: outbox})
+    ctx := context.Background()
+    email, _ := entity.DefaultEmailPolicy.Parse("joao@email.com")
+    
+    // Act
+    err := tx.WithinTx(ctx, func(ctx context.Context, repos Repositories) error {
+        user, _ := entity.NewUser("João Silva", email)
+        return repos.Users.Save(ctx, user)
+    })
+    
+    // Assert
+    if err != nil {
+        t.Fatalf("Expected no error, got %v", err)
+    }
+    if stored, _ := repo.FindByEmail(ctx, email.Canonical()); stored == nil {
+        t.Error("Expected user to be stored after commit")
+    }
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+type InMemoryUserRepository struct {
+    users map[string]*entity.User
+    // byEmail indexa os usuários ativos pelo email canônico, como o índice
+    // único parcial do Postgres; só é lido e alterado sob o mutex
+    byEmail map[string]string
+    mutex   sync.RWMutex
+}
+
+func NewInMemoryUserRepository() UserRepository {
+    return &InMemoryUserRepository{
+        users:   make(map[string]*entity.User),
+        byEmail: make(map[string]string),
+    }
+}
+
+// Save faz o mesmo compare-and-swap do Postgres: a versão recebida precisa
+// ser a que está guardada. O repositório guarda e devolve cópias, para que
+// duas requisições nunca compartilhem o mesmo *entity.User.
+func (r *InMemoryUserRepository) Save(ctx context.Context, u *entity.User) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    return r.save(u)
+}
+
+// save é o Save sem o lock, para quem já segura o mutex.
+func (r *InMemoryUserRepository) save(u *entity.User) error {
+    // Registros guardados têm versão >= 1; um usuário novo chega com zero
+    var current int64
+    existing, exists := r.users[u.ID]
+    if exists {
+        current = existing.Version
+    }
+    if current != u.Version {
+        return &entity.VersionConflictError{ID: u.ID, Version: u.Version}
+    }
+    if !u.IsDeleted() {
+        if owner, taken := r.byEmail[u.NormalizedEmail]; taken && owner != u.ID {
+            return &entity.EmailTakenError{Email: u.NormalizedEmail}
+        }
+    }
+    
+    u.Version++
+    r.put(u.ID, copyUser(u))
+    return nil
+}
+
+func (r *InMemoryUserRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+    
+    u, exists := r.users[id]
+    if !exists || u.IsDeleted() {
+        return nil, nil
+    }
+    return copyUser(u), nil
+}
+
+func (r *InMemoryUserRepository) FindDeletedByID(ctx context.Context, id string) (*entity.User, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+    
+    u, exists := r.users[id]
+    if !exists || !u.IsDeleted() {
+        return nil, nil
+    }
+    return copyUser(u), nil
+}
+
+func (r *InMemoryUserRepository) FindByEmail(ctx context.Context, normalizedEmail string) (*entity.User, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+    
+    id, exists := r.byEmail[normalizedEmail]
+    if !exists {
+        return nil, nil
+    }
+    return copyUser(r.users[id]), nil
+}
+
+func (r *InMemoryUserRepository) FindAll(ctx context.Context, opts ListOptions) ([]*entity.User, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+    
+    namePrefix := strings.ToLower(opts.NamePrefix)
+    emailPrefix := strings.ToLower(opts.EmailPrefix)
+    desc := opts.Sort == SortDesc
+    
+    var users []*entity.User
+    for _, u := range r.users {
+        if u.IsDeleted() {
+            continue
+        }
+        if namePrefix != "" && !strings.HasPrefix(strings.ToLower(u.Name), namePrefix) {
+            continue
+        }
+        if emailPrefix != "" && !strings.HasPrefix(strings.ToLower(u.Email), emailPrefix) {
+            continue
+        }
+        if !opts.CreatedAfter.IsZero() && u.CreatedAt.Before(opts.CreatedAfter) {
+            continue
+        }
+        if !opts.CreatedBefore.IsZero() && !u.CreatedAt.Before(opts.CreatedBefore) {
+            continue
+        }
+        if opts.After != nil {
+            cmp := compareKeyset(u, opts.After.CreatedAt, opts.After.ID)
+            if (desc && cmp >= 0) || (!desc && cmp <= 0) {
+                continue
+            }
+        }
+        users = append(users, copyUser(u))
+    }
+    
+    sort.Slice(users, func(i, j int) bool {
+        cmp := compareKeyset(users[i], users[j].CreatedAt, users[j].ID)
+        if desc {
+            return cmp > 0
+        }
+        return cmp < 0
+    })
+    
+    if opts.Limit > 0 && len(users) > opts.Limit {
+        users = users[:opts.Limit]
+    }
+    return users, nil
+}
+
+func (r *InMemoryUserRepository) Delete(ctx context.Context, id string) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    if _, exists := r.users[id]; !exists {
+        return entity.ErrUserNotFound
+    }
+    r.put(id, nil)
+    return nil
+}
+
+func (r *InMemoryUserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    var purged int64
+    for id, u := range r.users {
+        if u.IsDeleted() && u.DeletedAt.Before(before) {
+            r.put(id, nil)
+            purged++
+        }
+    }
+    return purged, nil
+}
+
+// put troca o registro guardado em id (nil remove) e mantém o índice de
+// emails em dia. Quem chama segura o mutex.
+func (r *InMemoryUserRepository) put(id string, u *entity.User) {
+    if existing, exists := r.users[id]; exists {
+        r.unindex(existing)
+        delete(r.users, id)
+    }
+    if u == nil {
+        return
+    }
+    r.users[id] = u
+    if !u.IsDeleted() {
+        r.byEmail[u.NormalizedEmail] = id
+    }
+}
+
+// unindex tira o usuário do índice de emails, se a entrada for dele.
+func (r *InMemoryUserRepository) unindex(u *entity.User) {
+    if r.byEmail[u.NormalizedEmail] == u.ID {
+        delete(r.byEmail, u.NormalizedEmail)
+    }
+}
+
+// copyUser copia o usuário sem os eventos pendentes: eles pertencem a quem
+// fez a alteração, não ao registro guardado.
+func copyUser(u *entity.User) *entity.User {
+    c := *u
+    c.ClearEvents()
+    return &c
+}
+
+// compareKeyset compara o usuário com a posição (created_at, id) informada.
+func compareKeyset(u *entity.User, createdAt time.Time, id string) int {
+    if cmp := u.CreatedAt.Compare(createdAt); cmp != 0 {
+        return cmp
+    }
+    return strings.Compare(u.ID, id)
+}
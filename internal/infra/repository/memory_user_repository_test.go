@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+func TestInMemoryUserRepository_SaveRejectsStaleVersion(t *testing.T) {
+    // Arrange
+    repo := NewUserRepository(InMemory, nil)
+    ctx := context.Background()
+    
+    email, _ := entity.DefaultEmailPolicy.Parse("joao@email.com")
+    user, _ := entity.NewUser("João Silva", email)
+    _ = repo.Save(ctx, user)
+    first, _ := repo.FindByID(ctx, user.ID)
+    second, _ := repo.FindByID(ctx, user.ID)
+    
+    // Act
+    _ = first.UpdateName("João Santos")
+    errFirst := repo.Save(ctx, first)
+    _ = second.UpdateName("João Souza")
+    errSecond := repo.Save(ctx, second)
+    
+    // Assert
+    if errFirst != nil {
+        t.Fatalf("Expected first save to succeed, got %v", errFirst)
+    }
+    if !errors.Is(errSecond, entity.ErrVersionConflict) {
+        t.Errorf("Expected ErrVersionConflict on stale save, got %v", errSecond)
+    }
+    if stored, _ := repo.FindByID(ctx, user.ID); stored.Name != "João Santos" {
+        t.Errorf("Expected stored name João Santos, got %s", stored.Name)
+    }
+}
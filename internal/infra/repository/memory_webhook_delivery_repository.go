@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+type InMemoryWebhookDeliveryRepository struct {
+    deliveries map[string]*entity.WebhookDelivery
+    // byEvent indexa webhookID+eventID para o Add ignorar repetições
+    byEvent    map[string]string
+    mutex      sync.RWMutex
+}
+
+func NewInMemoryWebhookDeliveryRepository() WebhookDeliveryRepository {
+    return &InMemoryWebhookDeliveryRepository{
+        deliveries: make(map[string]*entity.WebhookDelivery),
+        byEvent:    make(map[string]string),
+    }
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Add(ctx context.Context, deliveries ...*entity.WebhookDelivery) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    for _, d := range deliveries {
+        key := d.WebhookID + "/" + d.EventID
+        if _, exists := r.byEvent[key]; exists {
+            continue
+        }
+        r.byEvent[key] = d.ID
+        r.deliveries[d.ID] = copyWebhookDelivery(d)
+    }
+    return nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error) {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    var due []*entity.WebhookDelivery
+    for _, d := range r.deliveries {
+        if d.Status == entity.DeliveryPending && !d.NextAttemptAt.After(now) {
+            due = append(due, d)
+        }
+    }
+    sort.Slice(due, func(i, j int) bool {
+        if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+            return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+        }
+        return due[i].ID < due[j].ID
+    })
+    if limit > 0 && len(due) > limit {
+        due = due[:limit]
+    }
+
+    claimed := make([]*entity.WebhookDelivery, 0, len(due))
+    for _, d := range due {
+        d.NextAttemptAt = now.Add(lease)
+        c := copyWebhookDelivery(d)
+        c.History = nil
+        claimed = append(claimed, c)
+    }
+    sortWebhookDeliveries(claimed)
+    return claimed, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) RecordAttempts(ctx context.Context, d *entity.WebhookDelivery) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    stored, exists := r.deliveries[d.ID]
+    if !exists {
+        return nil
+    }
+    updated := copyWebhookDelivery(d)
+    updated.History = append(slices.Clone(stored.History), d.History...)
+    r.deliveries[d.ID] = updated
+    return nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) FindByID(ctx context.Context, webhookID, id string) (*entity.WebhookDelivery, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+
+    d, exists := r.deliveries[id]
+    if !exists || d.WebhookID != webhookID {
+        return nil, nil
+    }
+    return copyWebhookDelivery(d), nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) FindByWebhook(ctx context.Context, webhookID string, opts DeliveryListOptions) ([]*entity.WebhookDelivery, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+
+    var result []*entity.WebhookDelivery
+    for _, d := range r.deliveries {
+        if d.WebhookID != webhookID || (opts.Status != "" && d.Status != opts.Status) {
+            continue
+        }
+        if opts.After != nil && !deliveryBefore(d, opts.After) {
+            continue
+        }
+        c := copyWebhookDelivery(d)
+        c.History = nil
+        result = append(result, c)
+    }
+    sort.Slice(result, func(i, j int) bool {
+        return deliveryBefore(result[j], &Cursor{CreatedAt: result[i].CreatedAt, ID: result[i].ID})
+    })
+    if opts.Limit > 0 && len(result) > opts.Limit {
+        result = result[:opts.Limit]
+    }
+    return result, nil
+}
+
+// deliveryBefore diz se d vem depois do cursor na ordem decrescente de
+// (created_at, id).
+func deliveryBefore(d *entity.WebhookDelivery, c *Cursor) bool {
+    if !d.CreatedAt.Equal(c.CreatedAt) {
+        return d.CreatedAt.Before(c.CreatedAt)
+    }
+    return d.ID < c.ID
+}
+
+// sortWebhookDeliveries ordena um lote reservado pela criação, para que as
+// entregas de uma inscrição saiam na ordem dos eventos.
+func sortWebhookDeliveries(deliveries []*entity.WebhookDelivery) {
+    sort.Slice(deliveries, func(i, j int) bool {
+        if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+            return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+        }
+        return deliveries[i].ID < deliveries[j].ID
+    })
+}
+
+func copyWebhookDelivery(d *entity.WebhookDelivery) *entity.WebhookDelivery {
+    c := *d
+    c.History = slices.Clone(d.History)
+    return &c
+}
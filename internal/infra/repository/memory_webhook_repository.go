@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+type InMemoryWebhookRepository struct {
+    webhooks map[string]*entity.Webhook
+    mutex    sync.RWMutex
+}
+
+func NewInMemoryWebhookRepository() WebhookRepository {
+    return &InMemoryWebhookRepository{
+        webhooks: make(map[string]*entity.Webhook),
+    }
+}
+
+func (r *InMemoryWebhookRepository) Save(ctx context.Context, w *entity.Webhook) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    r.webhooks[w.ID] = copyWebhook(w)
+    return nil
+}
+
+func (r *InMemoryWebhookRepository) FindByID(ctx context.Context, id string) (*entity.Webhook, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+
+    w, exists := r.webhooks[id]
+    if !exists {
+        return nil, nil
+    }
+    return copyWebhook(w), nil
+}
+
+func (r *InMemoryWebhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+
+    webhooks := make([]*entity.Webhook, 0, len(r.webhooks))
+    for _, w := range r.webhooks {
+        webhooks = append(webhooks, copyWebhook(w))
+    }
+    sort.Slice(webhooks, func(i, j int) bool {
+        if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+            return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+        }
+        return webhooks[i].ID < webhooks[j].ID
+    })
+    return webhooks, nil
+}
+
+func (r *InMemoryWebhookRepository) Delete(ctx context.Context, id string) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    if _, exists := r.webhooks[id]; !exists {
+        return entity.ErrWebhookNotFound
+    }
+    delete(r.webhooks, id)
+    return nil
+}
+
+func copyWebhook(w *entity.Webhook) *entity.Webhook {
+    c := *w
+    c.Events = slices.Clone(w.Events)
+    return &c
+}
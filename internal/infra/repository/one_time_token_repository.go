@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OneTimeTokenRepository interface {
+    Save(ctx context.Context, token *entity.OneTimeToken) error
+    // Consume marca o token como usado e o devolve numa única operação, de
+    // modo que duas requisições concorrentes não consigam usá-lo. Devolve
+    // nil se o token não existir, já tiver sido usado ou estiver expirado.
+    Consume(ctx context.Context, purpose entity.TokenPurpose, tokenHash string, now time.Time) (*entity.OneTimeToken, error)
+    // InvalidateForUser descarta os tokens pendentes do usuário para o propósito.
+    InvalidateForUser(ctx context.Context, userID string, purpose entity.TokenPurpose, now time.Time) error
+}
+
+func NewOneTimeTokenRepository(repoType RepositoryType, pool *pgxpool.Pool, opts ...PostgresOption) OneTimeTokenRepository {
+    switch repoType {
+    case Postgres:
+        if pool == nil {
+            panic("pgxpool is required for postgres repository")
+        }
+        return NewPostgresOneTimeTokenRepository(pool, opts...)
+    default:
+        return NewInMemoryOneTimeTokenRepository()
+    }
+}
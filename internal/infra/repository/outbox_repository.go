@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxRepository guarda os eventos de domínio até o relay entregá-los.
+// Add é chamado dentro da transação da alteração; o resto, pelo relay.
+type OutboxRepository interface {
+    Add(ctx context.Context, messages ...*entity.OutboxMessage) error
+    // ClaimDue reserva até limit mensagens pendentes cuja próxima tentativa
+    // já venceu, adiando-as por lease. Outro relay não as recebe nesse
+    // intervalo; se este cair antes de chamar Update, elas voltam sozinhas.
+    ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxMessage, error)
+    Update(ctx context.Context, message *entity.OutboxMessage) error
+}
+
+func NewOutboxRepository(repoType RepositoryType, pool *pgxpool.Pool, opts ...PostgresOption) OutboxRepository {
+    switch repoType {
+    case Postgres:
+        if pool == nil {
+            panic("pgxpool is required for postgres repository")
+        }
+        return NewPostgresOutboxRepository(pool, opts...)
+    default:
+        return NewInMemoryOutboxRepository()
+    }
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresOneTimeTokenRepository struct {
+    postgresRepository
+}
+
+func NewPostgresOneTimeTokenRepository(pool *pgxpool.Pool, opts ...PostgresOption) OneTimeTokenRepository {
+    return &PostgresOneTimeTokenRepository{newPostgresRepository("PostgresOneTimeTokenRepository", pool, opts)}
+}
+
+func (r *PostgresOneTimeTokenRepository) Save(ctx context.Context, t *entity.OneTimeToken) (err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `
+        INSERT INTO one_time_tokens (id, user_id, purpose, token_hash, email, expires_at, created_at, used_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (id) DO UPDATE SET
+            used_at = EXCLUDED.used_at`
+    
+    ctx, finish := r.startQuery(ctx, "Save", query)
+    defer func() { finish(err) }()
+    
+    _, err = r.db.Exec(ctx, query, t.ID, t.UserID, t.Purpose, t.TokenHash, t.Email, t.ExpiresAt, t.CreatedAt, t.UsedAt)
+    if err != nil {
+        return fmt.Errorf("save one-time token: %w", err)
+    }
+    return nil
+}
+
+func (r *PostgresOneTimeTokenRepository) Consume(ctx context.Context, purpose entity.TokenPurpose, tokenHash string, now time.Time) (_ *entity.OneTimeToken, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    // O UPDATE condicional é o que garante o uso único sob concorrência
+    query := `
+        UPDATE one_time_tokens SET used_at = $3
+        WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > $3
+        RETURNING id, user_id, purpose, token_hash, email, expires_at, created_at, used_at`
+    
+    ctx, finish := r.startQuery(ctx, "Consume", query)
+    defer func() { finish(err) }()
+    
+    var t entity.OneTimeToken
+    err = r.db.QueryRow(ctx, query, tokenHash, purpose, now).Scan(
+        &t.ID, &t.UserID, &t.Purpose, &t.TokenHash, &t.Email, &t.ExpiresAt, &t.CreatedAt, &t.UsedAt,
+    )
+    
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("consume one-time token: %w", err)
+    }
+    return &t, nil
+}
+
+func (r *PostgresOneTimeTokenRepository) InvalidateForUser(ctx context.Context, userID string, purpose entity.TokenPurpose, now time.Time) (err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `UPDATE one_time_tokens SET used_at = $3 WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`
+    
+    ctx, finish := r.startQuery(ctx, "InvalidateForUser", query)
+    defer func() { finish(err) }()
+    
+    if _, err = r.db.Exec(ctx, query, userID, purpose, now); err != nil {
+        return fmt.Errorf("invalidate one-time tokens: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresOutboxRepository struct {
+    postgresRepository
+}
+
+func NewPostgresOutboxRepository(pool *pgxpool.Pool, opts ...PostgresOption) OutboxRepository {
+    return &PostgresOutboxRepository{newPostgresRepository("PostgresOutboxRepository", pool, opts)}
+}
+
+func (r *PostgresOutboxRepository) Add(ctx context.Context, messages ...*entity.OutboxMessage) (err error) {
+    if len(messages) == 0 {
+        return nil
+    }
+
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `
+        INSERT INTO outbox (id, event_type, aggregate_id, payload, occurred_at, status, attempts, next_attempt_at, last_error, created_at, delivered_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11)`
+
+    ctx, finish := r.startQuery(ctx, "Add", query)
+    defer func() { finish(err) }()
+
+    batch := &pgx.Batch{}
+    for _, m := range messages {
+        batch.Queue(query,
+            m.ID, m.EventType, m.AggregateID, m.Payload, m.OccurredAt, m.Status, m.Attempts, m.NextAttemptAt, m.LastError, m.CreatedAt, m.DeliveredAt,
+        )
+    }
+    if err := r.db.SendBatch(ctx, batch).Close(); err != nil {
+        return fmt.Errorf("add outbox messages: %w", err)
+    }
+    return nil
+}
+
+// ClaimDue usa FOR UPDATE SKIP LOCKED para que relays em paralelo não
+// reservem a mesma mensagem.
+func (r *PostgresOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) (_ []*entity.OutboxMessage, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `
+        UPDATE outbox SET next_attempt_at = $2
+        WHERE id IN (
+            SELECT id FROM outbox
+            WHERE status = 'pending' AND next_attempt_at <= $1
+            ORDER BY created_at, id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + outboxColumns
+
+    ctx, finish := r.startQuery(ctx, "ClaimDue", query)
+    defer func() { finish(err) }()
+
+    rows, err := r.db.Query(ctx, query, now, now.Add(lease), limit)
+    if err != nil {
+        return nil, fmt.Errorf("claim outbox messages: %w", err)
+    }
+    defer rows.Close()
+
+    var messages []*entity.OutboxMessage
+    for rows.Next() {
+        var m entity.OutboxMessage
+        if err := rows.Scan(
+            &m.ID, &m.EventType, &m.AggregateID, &m.Payload, &m.OccurredAt, &m.Status, &m.Attempts, &m.NextAttemptAt, &m.LastError, &m.CreatedAt, &m.DeliveredAt,
+        ); err != nil {
+            return nil, fmt.Errorf("scan outbox message: %w", err)
+        }
+        messages = append(messages, &m)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("claim outbox messages: %w", err)
+    }
+
+    // RETURNING não respeita o ORDER BY da subconsulta
+    sortOutboxMessages(messages)
+    return messages, nil
+}
+
+func (r *PostgresOutboxRepository) Update(ctx context.Context, m *entity.OutboxMessage) (err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `
+        UPDATE outbox SET
+            status = $2,
+            attempts = $3,
+            next_attempt_at = $4,
+            last_error = NULLIF($5, ''),
+            delivered_at = $6
+        WHERE id = $1`
+
+    ctx, finish := r.startQuery(ctx, "Update", query)
+    defer func() { finish(err) }()
+
+    _, err = r.db.Exec(ctx, query, m.ID, m.Status, m.Attempts, m.NextAttemptAt, m.LastError, m.DeliveredAt)
+    if err != nil {
+        return fmt.Errorf("update outbox message: %w", err)
+    }
+    return nil
+}
+
+// outboxColumns é a ordem de colunas esperada pelo Scan de ClaimDue.
+const outboxColumns = `id, event_type, aggregate_id, payload, occurred_at, status, attempts, next_attempt_at, COALESCE(last_error, ''), created_at, delivered_at`
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresRefreshTokenRepository struct {
+    postgresRepository
+}
+
+func NewPostgresRefreshTokenRepository(pool *pgxpool.Pool, opts ...PostgresOption) RefreshTokenRepository {
+    return &PostgresRefreshTokenRepository{newPostgresRepository("PostgresRefreshTokenRepository", pool, opts)}
+}
+
+func (r *PostgresRefreshTokenRepository) Save(ctx context.Context, t *entity.RefreshToken) (err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `
+        INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at, created_at, revoked_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (id) DO UPDATE SET
+            revoked_at = EXCLUDED.revoked_at`
+    
+    ctx, finish := r.startQuery(ctx, "Save", query)
+    defer func() { finish(err) }()
+    
+    _, err = r.db.Exec(ctx, query, t.ID, t.UserID, t.TokenHash, t.ExpiresAt, t.CreatedAt, t.RevokedAt)
+    if err != nil {
+        return fmt.Errorf("save refresh token: %w", err)
+    }
+    return nil
+}
+
+func (r *PostgresRefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (_ *entity.RefreshToken, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `
+        SELECT id, user_id, token_hash, expires_at, created_at, revoked_at
+        FROM refresh_tokens WHERE token_hash = $1`
+    
+    ctx, finish := r.startQuery(ctx, "FindByHash", query)
+    defer func() { finish(err) }()
+    
+    var t entity.RefreshToken
+    err = r.db.QueryRow(ctx, query, tokenHash).Scan(
+        &t.ID, &t.UserID, &t.TokenHash, &t.ExpiresAt, &t.CreatedAt, &t.RevokedAt,
+    )
+    
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("find refresh token: %w", err)
+    }
+    return &t, nil
+}
+
+func (r *PostgresRefreshTokenRepository) Consume(ctx context.Context, tokenHash string, now time.Time) (_ *entity.RefreshToken, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    // O UPDATE condicional é o que garante a rotação única sob concorrência
+    query := `
+        UPDATE refresh_tokens SET revoked_at = $2
+        WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > $2
+        RETURNING id, user_id, token_hash, expires_at, created_at, revoked_at`
+    
+    ctx, finish := r.startQuery(ctx, "Consume", query)
+    defer func() { finish(err) }()
+    
+    var t entity.RefreshToken
+    err = r.db.QueryRow(ctx, query, tokenHash, now).Scan(
+        &t.ID, &t.UserID, &t.TokenHash, &t.ExpiresAt, &t.CreatedAt, &t.RevokedAt,
+    )
+    
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("consume refresh token: %w", err)
+    }
+    return &t, nil
+}
+
+func (r *PostgresRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string, at time.Time) (err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `UPDATE refresh_tokens SET revoked_at = $2 WHERE user_id = $1 AND revoked_at IS NULL`
+    
+    ctx, finish := r.startQuery(ctx, "RevokeAllForUser", query)
+    defer func() { finish(err) }()
+    
+    if _, err = r.db.Exec(ctx, query, userID, at); err != nil {
+        return fmt.Errorf("revoke refresh tokens: %w", err)
+    }
+    return nil
+}
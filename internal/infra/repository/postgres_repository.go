@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// uniqueViolation é o SQLSTATE 23505 (unique_violation).
+const uniqueViolation = "23505"
+
+// querier é o que os repositórios usam do banco; tanto o pool quanto uma
+// pgx.Tx servem, e é assim que o mesmo repositório roda dentro de uma
+// transação.
+type querier interface {
+    Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+    Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+    QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+    SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+var (
+    _ querier = (*pgxpool.Pool)(nil)
+    _ querier = (pgx.Tx)(nil)
+)
+
+// postgresRepository concentra o que todo repositório Postgres compartilha:
+// conexão, timeout por query, log e span de cada chamada.
+type postgresRepository struct {
+    name         string
+    db           querier
+    queryTimeout time.Duration
+    logger       *slog.Logger
+}
+
+type PostgresOption func(*postgresRepository)
+
+// WithQueryTimeout limita a duração de cada query, além do prazo já
+// carregado pelo contexto da requisição.
+func WithQueryTimeout(timeout time.Duration) PostgresOption {
+    return func(r *postgresRepository) {
+        r.queryTimeout = timeout
+    }
+}
+
+func WithLogger(logger *slog.Logger) PostgresOption {
+    return func(r *postgresRepository) {
+        r.logger = logger
+    }
+}
+
+func newPostgresRepository(name string, db querier, opts []PostgresOption) postgresRepository {
+    r := postgresRepository{name: name, db: db, logger: slog.Default()}
+    for _, opt := range opts {
+        opt(&r)
+    }
+    return r
+}
+
+// startQuery abre o span da query e devolve a função que o encerra e
+// registra a duração no log de debug.
+func (r *postgresRepository) startQuery(ctx context.Context, operation, query string) (context.Context, func(error)) {
+    start := time.Now()
+    ctx, span := startQuerySpan(ctx, r.name+"."+operation, operation, query)
+    
+    return ctx, func(err error) {
+        endSpan(span, err)
+        
+        attrs := []any{"repository", r.name, "operation", operation, "duration_ms", float64(time.Since(start).Microseconds()) / 1000}
+        if err != nil {
+            r.logger.WarnContext(ctx, "query failed", append(attrs, "error", err)...)
+            return
+        }
+        r.logger.DebugContext(ctx, "query executed", attrs...)
+    }
+}
+
+func (r *postgresRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+    if r.queryTimeout <= 0 {
+        return ctx, func() {}
+    }
+    return context.WithTimeout(ctx, r.queryTimeout)
+}
+
+// isUniqueViolation diz se err veio da violação do índice único constraint.
+func isUniqueViolation(err error, constraint string) bool {
+    var pgErr *pgconn.PgError
+    return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == constraint
+}
+
+// isUUID diz se id pode ser comparado com uma coluna uuid. Sem essa
+// checagem, um id malformado vindo da URL faz o Postgres falhar com 22P02
+// em vez de simplesmente não encontrar nada.
+func isUUID(id string) bool {
+    _, err := uuid.Parse(id)
+    return err == nil
+}
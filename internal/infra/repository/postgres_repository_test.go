@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+)
+
+func TestPostgresRepositories_MalformedIDIsNotFound(t *testing.T) {
+    // Arrange: sem pool, qualquer ida ao banco entraria em pânico
+    users := NewPostgresUserRepository(nil)
+    webhooks := NewPostgresWebhookRepository(nil)
+    deliveries := NewPostgresWebhookDeliveryRepository(nil)
+    ctx := context.Background()
+    id := "nao-e-um-uuid"
+    
+    // Act & Assert
+    if user, err := users.FindByID(ctx, id); user != nil || err != nil {
+        t.Errorf("Expected FindByID to find nothing, got %v, %v", user, err)
+    }
+    if user, err := users.FindDeletedByID(ctx, id); user != nil || err != nil {
+        t.Errorf("Expected FindDeletedByID to find nothing, got %v, %v", user, err)
+    }
+    if err := users.Delete(ctx, id); !errors.Is(err, entity.ErrUserNotFound) {
+        t.Errorf("Expected ErrUserNotFound from Delete, got %v", err)
+    }
+    if webhook, err := webhooks.FindByID(ctx, id); webhook != nil || err != nil {
+        t.Errorf("Expected webhook FindByID to find nothing, got %v, %v", webhook, err)
+    }
+    if err := webhooks.Delete(ctx, id); !errors.Is(err, entity.ErrWebhookNotFound) {
+        t.Errorf("Expected ErrWebhookNotFound from Delete, got %v", err)
+    }
+    if delivery, err := deliveries.FindByID(ctx, id, id); delivery != nil || err != nil {
+        t.Errorf("Expected delivery FindByID to find nothing, got %v, %v", delivery, err)
+    }
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+    // serializationFailure e deadlockDetected são os SQLSTATE em que o
+    // Postgres pede para repetir a transação
+    serializationFailure = "40001"
+    deadlockDetected     = "40P01"
+
+    txRetryBaseDelay = 10 * time.Millisecond
+)
+
+// PostgresTxManager abre uma pgx.Tx por unidade de trabalho e entrega a fn
+// repositórios que usam essa transação no lugar do pool.
+type PostgresTxManager struct {
+    pool *pgxpool.Pool
+    txOptions
+}
+
+func NewPostgresTxManager(pool *pgxpool.Pool, opts ...TxOption) TxManager {
+    return &PostgresTxManager{
+        pool:      pool,
+        txOptions: newTxOptions(opts),
+    }
+}
+
+// WithinTx repete fn, numa transação nova, enquanto ela falhar por
+// serialização ou deadlock, até maxRetries vezes.
+func (m *PostgresTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error {
+    for attempt := 0; ; attempt++ {
+        err := m.run(ctx, fn)
+        if err == nil || !isRetryableTxError(err) || attempt >= m.maxRetries {
+            return err
+        }
+
+        m.logger.WarnContext(ctx, "transaction conflict, retrying", "attempt", attempt+1, "error", err)
+        // Espera um pouco, com jitter, para que as transações em conflito
+        // não voltem a colidir no mesmo instante
+        delay := txRetryBaseDelay << attempt
+        delay += rand.N(delay)
+        select {
+        case <-ctx.Done():
+            return err
+        case <-time.After(delay):
+        }
+    }
+}
+
+func (m *PostgresTxManager) run(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error {
+    tx, err := m.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: m.isolation})
+    if err != nil {
+        return fmt.Errorf("begin transaction: %w", err)
+    }
+    // Depois do Commit o Rollback não faz nada; antes dele, desfaz tudo,
+    // inclusive se fn entrar em pânico
+    defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+    repos := Repositories{
+        Users:         &PostgresUserRepository{newPostgresRepository("PostgresUserRepository", tx, m.repoOpts)},
+        Outbox:        &PostgresOutboxRepository{newPostgresRepository("PostgresOutboxRepository", tx, m.repoOpts)},
+        RefreshTokens: &PostgresRefreshTokenRepository{newPostgresRepository("PostgresRefreshTokenRepository", tx, m.repoOpts)},
+        OneTimeTokens: &PostgresOneTimeTokenRepository{newPostgresRepository("PostgresOneTimeTokenRepository", tx, m.repoOpts)},
+    }
+    if err := fn(ctx, m.decorate(repos)); err != nil {
+        return err
+    }
+
+    if err := tx.Commit(ctx); err != nil {
+        return fmt.Errorf("commit transaction: %w", err)
+    }
+    return nil
+}
+
+func isRetryableTxError(err error) bool {
+    var pgErr *pgconn.PgError
+    if !errors.As(err, &pgErr) {
+        return false
+    }
+    return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
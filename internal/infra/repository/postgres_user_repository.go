@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresUserRepository struct {
+    postgresRepository
+}
+
+func NewPostgresUserRepository(pool *pgxpool.Pool, opts ...PostgresOption) UserRepository {
+    return &PostgresUserRepository{newPostgresRepository("PostgresUserRepository", pool, opts)}
+}
+
+// usersEmailActiveKey é o índice único parcial que garante um só usuário
+// ativo por email canônico (migração 0006).
+const usersEmailActiveKey = "users_email_normalized_active_key"
+
+// Save insere usuários novos (Version zero) e atualiza os existentes com
+// compare-and-swap na coluna version. Em caso de sucesso, u.Version passa a
+// ser a versão gravada. A unicidade do email fica a cargo do índice, para
+// que dois cadastros simultâneos não passem os dois.
+func (r *PostgresUserRepository) Save(ctx context.Context, u *entity.User) (err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    if u.Version == 0 {
+        err = r.insert(ctx, u)
+    } else {
+        err = r.update(ctx, u)
+    }
+    if err != nil {
+        return err
+    }
+    
+    u.Version++
+    return nil
+}
+
+func (r *PostgresUserRepository) insert(ctx context.Context, u *entity.User) (err error) {
+    query := `
+        INSERT INTO users (id, name, email, email_normalized, password_hash, email_verified_at, created_at, updated_at, deleted_at, version) 
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, 1)
+        ON CONFLICT (id) DO NOTHING`
+    
+    ctx, finish := r.startQuery(ctx, "Insert", query)
+    defer func() { finish(err) }()
+    
+    result, err := r.db.Exec(ctx, query,
+        u.ID, u.Name, u.Email, u.NormalizedEmail, u.PasswordHash, u.EmailVerifiedAt, u.CreatedAt, u.UpdatedAt, u.DeletedAt,
+    )
+    if isUniqueViolation(err, usersEmailActiveKey) {
+        return &entity.EmailTakenError{Email: u.NormalizedEmail}
+    }
+    if err != nil {
+        return fmt.Errorf("insert user: %w", err)
+    }
+    if result.RowsAffected() == 0 {
+        return &entity.VersionConflictError{ID: u.ID, Version: u.Version}
+    }
+    return nil
+}
+
+func (r *PostgresUserRepository) update(ctx context.Context, u *entity.User) (err error) {
+    query := `
+        UPDATE users SET
+            name = $2,
+            email = $3,
+            email_normalized = $4,
+            password_hash = NULLIF($5, ''),
+            email_verified_at = $6,
+            updated_at = $7,
+            deleted_at = $8,
+            version = version + 1
+        WHERE id = $1 AND version = $9`
+    
+    ctx, finish := r.startQuery(ctx, "Update", query)
+    defer func() { finish(err) }()
+    
+    result, err := r.db.Exec(ctx, query,
+        u.ID, u.Name, u.Email, u.NormalizedEmail, u.PasswordHash, u.EmailVerifiedAt, u.UpdatedAt, u.DeletedAt, u.Version,
+    )
+    if isUniqueViolation(err, usersEmailActiveKey) {
+        return &entity.EmailTakenError{Email: u.NormalizedEmail}
+    }
+    if err != nil {
+        return fmt.Errorf("update user: %w", err)
+    }
+    if result.RowsAffected() == 0 {
+        return &entity.VersionConflictError{ID: u.ID, Version: u.Version}
+    }
+    return nil
+}
+
+func (r *PostgresUserRepository) FindByID(ctx context.Context, id string) (_ *entity.User, err error) {
+    if !isUUID(id) {
+        return nil, nil
+    }
+    
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `SELECT ` + userColumns + ` FROM users WHERE id = $1 AND deleted_at IS NULL`
+    
+    ctx, finish := r.startQuery(ctx, "FindByID", query)
+    defer func() { finish(err) }()
+    
+    u, err := scanUser(r.db.QueryRow(ctx, query, id))
+    
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("find user by id: %w", err)
+    }
+    return u, nil
+}
+
+func (r *PostgresUserRepository) FindDeletedByID(ctx context.Context, id string) (_ *entity.User, err error) {
+    if !isUUID(id) {
+        return nil, nil
+    }
+    
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `SELECT ` + userColumns + ` FROM users WHERE id = $1 AND deleted_at IS NOT NULL`
+    
+    ctx, finish := r.startQuery(ctx, "FindDeletedByID", query)
+    defer func() { finish(err) }()
+    
+    u, err := scanUser(r.db.QueryRow(ctx, query, id))
+    
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("find deleted user by id: %w", err)
+    }
+    return u, nil
+}
+
+func (r *PostgresUserRepository) FindByEmail(ctx context.Context, normalizedEmail string) (_ *entity.User, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `SELECT ` + userColumns + ` FROM users WHERE email_normalized = $1 AND deleted_at IS NULL`
+    
+    ctx, finish := r.startQuery(ctx, "FindByEmail", query)
+    defer func() { finish(err) }()
+    
+    u, err := scanUser(r.db.QueryRow(ctx, query, normalizedEmail))
+    
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("find user by email: %w", err)
+    }
+    return u, nil
+}
+
+func (r *PostgresUserRepository) FindAll(ctx context.Context, opts ListOptions) (_ []*entity.User, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    var (
+        conditions = []string{"deleted_at IS NULL"}
+        args       []any
+    )
+    arg := func(v any) string {
+        args = append(args, v)
+        return fmt.Sprintf("$%d", len(args))
+    }
+    
+    if opts.NamePrefix != "" {
+        conditions = append(conditions, "name ILIKE "+arg(likePrefix(opts.NamePrefix)))
+    }
+    if opts.EmailPrefix != "" {
+        conditions = append(conditions, "email ILIKE "+arg(likePrefix(opts.EmailPrefix)))
+    }
+    if !opts.CreatedAfter.IsZero() {
+        conditions = append(conditions, "created_at >= "+arg(opts.CreatedAfter))
+    }
+    if !opts.CreatedBefore.IsZero() {
+        conditions = append(conditions, "created_at < "+arg(opts.CreatedBefore))
+    }
+    
+    order, op := "ASC", ">"
+    if opts.Sort == SortDesc {
+        order, op = "DESC", "<"
+    }
+    if opts.After != nil {
+        conditions = append(conditions, fmt.Sprintf("(created_at, id) %s (%s, %s)",
+            op, arg(opts.After.CreatedAt), arg(opts.After.ID)))
+    }
+    
+    query := `SELECT ` + userColumns + ` FROM users WHERE ` + strings.Join(conditions, " AND ")
+    query += fmt.Sprintf(" ORDER BY created_at %s, id %s", order, order)
+    if opts.Limit > 0 {
+        query += " LIMIT " + arg(opts.Limit)
+    }
+    
+    ctx, finish := r.startQuery(ctx, "FindAll", query)
+    defer func() { finish(err) }()
+    
+    rows, err := r.db.Query(ctx, query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("find users: %w", err)
+    }
+    defer rows.Close()
+    
+    var users []*entity.User
+    for rows.Next() {
+        u, err := scanUser(rows)
+        if err != nil {
+            return nil, fmt.Errorf("scan user: %w", err)
+        }
+        users = append(users, u)
+    }
+    
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("find users: %w", err)
+    }
+    return users, nil
+}
+
+func (r *PostgresUserRepository) Delete(ctx context.Context, id string) (err error) {
+    if !isUUID(id) {
+        return entity.ErrUserNotFound
+    }
+    
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `DELETE FROM users WHERE id = $1`
+    
+    ctx, finish := r.startQuery(ctx, "Delete", query)
+    defer func() { finish(err) }()
+    
+    result, err := r.db.Exec(ctx, query, id)
+    if err != nil {
+        return fmt.Errorf("delete user: %w", err)
+    }
+    
+    rowsAffected := result.RowsAffected()
+    if rowsAffected == 0 {
+        return entity.ErrUserNotFound
+    }
+    
+    return nil
+}
+
+func (r *PostgresUserRepository) PurgeDeleted(ctx context.Context, before time.Time) (_ int64, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+    
+    query := `DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+    
+    ctx, finish := r.startQuery(ctx, "PurgeDeleted", query)
+    defer func() { finish(err) }()
+    
+    result, err := r.db.Exec(ctx, query, before)
+    if err != nil {
+        return 0, fmt.Errorf("purge deleted users: %w", err)
+    }
+    return result.RowsAffected(), nil
+}
+
+// userColumns é a ordem de colunas esperada por scanUser.
+const userColumns = `id, name, email, email_normalized, COALESCE(password_hash, ''), email_verified_at, created_at, updated_at, deleted_at, version`
+
+func scanUser(row pgx.Row) (*entity.User, error) {
+    var u entity.User
+    err := row.Scan(&u.ID, &u.Name, &u.Email, &u.NormalizedEmail, &u.PasswordHash, &u.EmailVerifiedAt, &u.CreatedAt, &u.UpdatedAt, &u.DeletedAt, &u.Version)
+    if err != nil {
+        return nil, err
+    }
+    return &u, nil
+}
+
+// likePrefix escapa os curingas do LIKE para que o filtro seja um prefixo literal.
+func likePrefix(s string) string {
+    replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+    return replacer.Replace(s) + "%"
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWebhookDeliveryRepository struct {
+    postgresRepository
+}
+
+func NewPostgresWebhookDeliveryRepository(pool *pgxpool.Pool, opts ...PostgresOption) WebhookDeliveryRepository {
+    return &PostgresWebhookDeliveryRepository{newPostgresRepository("PostgresWebhookDeliveryRepository", pool, opts)}
+}
+
+func (r *PostgresWebhookDeliveryRepository) Add(ctx context.Context, deliveries ...*entity.WebhookDelivery) (err error) {
+    if len(deliveries) == 0 {
+        return nil
+    }
+
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `
+        INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        ON CONFLICT (webhook_id, event_id) DO NOTHING`
+
+    ctx, finish := r.startQuery(ctx, "Add", query)
+    defer func() { finish(err) }()
+
+    batch := &pgx.Batch{}
+    for _, d := range deliveries {
+        batch.Queue(query,
+            d.ID, d.WebhookID, d.EventID, d.EventType, d.Payload, d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt,
+        )
+    }
+    if err := r.db.SendBatch(ctx, batch).Close(); err != nil {
+        return fmt.Errorf("add webhook deliveries: %w", err)
+    }
+    return nil
+}
+
+// ClaimDue usa FOR UPDATE SKIP LOCKED, como o outbox, para que dispatchers
+// em paralelo não reservem a mesma entrega.
+func (r *PostgresWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) (_ []*entity.WebhookDelivery, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `
+        UPDATE webhook_deliveries SET next_attempt_at = $2
+        WHERE id IN (
+            SELECT id FROM webhook_deliveries
+            WHERE status = 'pending' AND next_attempt_at <= $1
+            ORDER BY next_attempt_at, id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + deliveryColumns
+
+    ctx, finish := r.startQuery(ctx, "ClaimDue", query)
+    defer func() { finish(err) }()
+
+    rows, err := r.db.Query(ctx, query, now, now.Add(lease), limit)
+    if err != nil {
+        return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+    }
+    defer rows.Close()
+
+    var deliveries []*entity.WebhookDelivery
+    for rows.Next() {
+        var d entity.WebhookDelivery
+        if err := rows.Scan(deliveryFields(&d)...); err != nil {
+            return nil, fmt.Errorf("scan webhook delivery: %w", err)
+        }
+        deliveries = append(deliveries, &d)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+    }
+
+    // RETURNING não tem ordem definida
+    sortWebhookDeliveries(deliveries)
+    return deliveries, nil
+}
+
+func (r *PostgresWebhookDeliveryRepository) RecordAttempts(ctx context.Context, d *entity.WebhookDelivery) (err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `
+        UPDATE webhook_deliveries SET
+            status = $2,
+            attempts = $3,
+            next_attempt_at = $4,
+            last_status_code = NULLIF($5, 0),
+            last_error = NULLIF($6, ''),
+            delivered_at = $7
+        WHERE id = $1`
+    attemptQuery := `
+        INSERT INTO webhook_delivery_attempts (delivery_id, number, status_code, error, duration_ms, attempted_at)
+        VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, ''), $5, $6)
+        ON CONFLICT (delivery_id, number) DO NOTHING`
+
+    ctx, finish := r.startQuery(ctx, "RecordAttempts", query)
+    defer func() { finish(err) }()
+
+    // O batch roda numa transação implícita: estado e tentativas vão juntos
+    batch := &pgx.Batch{}
+    batch.Queue(query, d.ID, d.Status, d.Attempts, d.NextAttemptAt, d.LastStatusCode, d.LastError, d.DeliveredAt)
+    for _, a := range d.History {
+        batch.Queue(attemptQuery, d.ID, a.Number, a.StatusCode, a.Error, a.Duration.Milliseconds(), a.At)
+    }
+    if err := r.db.SendBatch(ctx, batch).Close(); err != nil {
+        return fmt.Errorf("record webhook delivery attempts: %w", err)
+    }
+    return nil
+}
+
+func (r *PostgresWebhookDeliveryRepository) FindByID(ctx context.Context, webhookID, id string) (_ *entity.WebhookDelivery, err error) {
+    if !isUUID(webhookID) || !isUUID(id) {
+        return nil, nil
+    }
+
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1 AND webhook_id = $2`
+    attemptQuery := `
+        SELECT number, COALESCE(status_code, 0), COALESCE(error, ''), duration_ms, attempted_at
+        FROM webhook_delivery_attempts WHERE delivery_id = $1 ORDER BY number`
+
+    ctx, finish := r.startQuery(ctx, "FindByID", query)
+    defer func() { finish(err) }()
+
+    var d entity.WebhookDelivery
+    err = r.db.QueryRow(ctx, query, id, webhookID).Scan(deliveryFields(&d)...)
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("find webhook delivery: %w", err)
+    }
+
+    rows, err := r.db.Query(ctx, attemptQuery, id)
+    if err != nil {
+        return nil, fmt.Errorf("find webhook delivery attempts: %w", err)
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var (
+            a          entity.WebhookAttempt
+            durationMs int64
+        )
+        if err := rows.Scan(&a.Number, &a.StatusCode, &a.Error, &durationMs, &a.At); err != nil {
+            return nil, fmt.Errorf("scan webhook delivery attempt: %w", err)
+        }
+        a.Duration = time.Duration(durationMs) * time.Millisecond
+        d.History = append(d.History, a)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("find webhook delivery attempts: %w", err)
+    }
+    return &d, nil
+}
+
+func (r *PostgresWebhookDeliveryRepository) FindByWebhook(ctx context.Context, webhookID string, opts DeliveryListOptions) (_ []*entity.WebhookDelivery, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    var (
+        conditions []string
+        args       []any
+    )
+    arg := func(v any) string {
+        args = append(args, v)
+        return fmt.Sprintf("$%d", len(args))
+    }
+
+    conditions = append(conditions, "webhook_id = "+arg(webhookID))
+    if opts.Status != "" {
+        conditions = append(conditions, "status = "+arg(opts.Status))
+    }
+    if opts.After != nil {
+        conditions = append(conditions, fmt.Sprintf("(created_at, id) < (%s, %s)", arg(opts.After.CreatedAt), arg(opts.After.ID)))
+    }
+
+    query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE ` + strings.Join(conditions, " AND ")
+    query += " ORDER BY created_at DESC, id DESC"
+    if opts.Limit > 0 {
+        query += " LIMIT " + arg(opts.Limit)
+    }
+
+    ctx, finish := r.startQuery(ctx, "FindByWebhook", query)
+    defer func() { finish(err) }()
+
+    rows, err := r.db.Query(ctx, query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("find webhook deliveries: %w", err)
+    }
+    defer rows.Close()
+
+    var deliveries []*entity.WebhookDelivery
+    for rows.Next() {
+        var d entity.WebhookDelivery
+        if err := rows.Scan(deliveryFields(&d)...); err != nil {
+            return nil, fmt.Errorf("scan webhook delivery: %w", err)
+        }
+        deliveries = append(deliveries, &d)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("find webhook deliveries: %w", err)
+    }
+    return deliveries, nil
+}
+
+// deliveryColumns é a ordem de colunas esperada por deliveryFields.
+const deliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, COALESCE(last_status_code, 0), COALESCE(last_error, ''), created_at, delivered_at`
+
+func deliveryFields(d *entity.WebhookDelivery) []any {
+    return []any{
+        &d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+        &d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt,
+    }
+}
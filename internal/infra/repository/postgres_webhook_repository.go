@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWebhookRepository struct {
+    postgresRepository
+}
+
+func NewPostgresWebhookRepository(pool *pgxpool.Pool, opts ...PostgresOption) WebhookRepository {
+    return &PostgresWebhookRepository{newPostgresRepository("PostgresWebhookRepository", pool, opts)}
+}
+
+func (r *PostgresWebhookRepository) Save(ctx context.Context, w *entity.Webhook) (err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `
+        INSERT INTO webhooks (id, url, secret, events, description, active, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (id) DO UPDATE SET
+            url = EXCLUDED.url,
+            secret = EXCLUDED.secret,
+            events = EXCLUDED.events,
+            description = EXCLUDED.description,
+            active = EXCLUDED.active,
+            updated_at = EXCLUDED.updated_at`
+
+    ctx, finish := r.startQuery(ctx, "Save", query)
+    defer func() { finish(err) }()
+
+    // events é NOT NULL: a lista vazia (todos os eventos) não pode virar NULL
+    events := w.Events
+    if events == nil {
+        events = []string{}
+    }
+    _, err = r.db.Exec(ctx, query, w.ID, w.URL, w.Secret, events, w.Description, w.Active, w.CreatedAt, w.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("save webhook: %w", err)
+    }
+    return nil
+}
+
+func (r *PostgresWebhookRepository) FindByID(ctx context.Context, id string) (_ *entity.Webhook, err error) {
+    if !isUUID(id) {
+        return nil, nil
+    }
+
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+    ctx, finish := r.startQuery(ctx, "FindByID", query)
+    defer func() { finish(err) }()
+
+    w, err := scanWebhook(r.db.QueryRow(ctx, query, id))
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("find webhook: %w", err)
+    }
+    return w, nil
+}
+
+func (r *PostgresWebhookRepository) FindAll(ctx context.Context) (_ []*entity.Webhook, err error) {
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY created_at, id`
+
+    ctx, finish := r.startQuery(ctx, "FindAll", query)
+    defer func() { finish(err) }()
+
+    rows, err := r.db.Query(ctx, query)
+    if err != nil {
+        return nil, fmt.Errorf("find webhooks: %w", err)
+    }
+    defer rows.Close()
+
+    var webhooks []*entity.Webhook
+    for rows.Next() {
+        w, err := scanWebhook(rows)
+        if err != nil {
+            return nil, fmt.Errorf("scan webhook: %w", err)
+        }
+        webhooks = append(webhooks, w)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("find webhooks: %w", err)
+    }
+    return webhooks, nil
+}
+
+// Delete apaga também as entregas, pelo ON DELETE CASCADE.
+func (r *PostgresWebhookRepository) Delete(ctx context.Context, id string) (err error) {
+    if !isUUID(id) {
+        return entity.ErrWebhookNotFound
+    }
+
+    ctx, cancel := r.withTimeout(ctx)
+    defer cancel()
+
+    query := `DELETE FROM webhooks WHERE id = $1`
+
+    ctx, finish := r.startQuery(ctx, "Delete", query)
+    defer func() { finish(err) }()
+
+    result, err := r.db.Exec(ctx, query, id)
+    if err != nil {
+        return fmt.Errorf("delete webhook: %w", err)
+    }
+    if result.RowsAffected() == 0 {
+        return entity.ErrWebhookNotFound
+    }
+    return nil
+}
+
+// webhookColumns é a ordem de colunas esperada por scanWebhook.
+const webhookColumns = `id, url, secret, events, description, active, created_at, updated_at`
+
+func scanWebhook(row pgx.Row) (*entity.Webhook, error) {
+    var w entity.Webhook
+    if err := row.Scan(&w.ID, &w.URL, &w.Secret, &w.Events, &w.Description, &w.Active, &w.CreatedAt, &w.UpdatedAt); err != nil {
+        return nil, err
+    }
+    return &w, nil
+}
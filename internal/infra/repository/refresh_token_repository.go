@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RefreshTokenRepository interface {
+    Save(ctx context.Context, token *entity.RefreshToken) error
+    FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+    // Consume revoga o token e o devolve numa única operação, de modo que
+    // duas requisições concorrentes não consigam rotacioná-lo. Devolve nil
+    // se o token não existir, já estiver revogado ou estiver expirado.
+    Consume(ctx context.Context, tokenHash string, now time.Time) (*entity.RefreshToken, error)
+    RevokeAllForUser(ctx context.Context, userID string, at time.Time) error
+}
+
+func NewRefreshTokenRepository(repoType RepositoryType, pool *pgxpool.Pool, opts ...PostgresOption) RefreshTokenRepository {
+    switch repoType {
+    case Postgres:
+        if pool == nil {
+            panic("pgxpool is required for postgres repository")
+        }
+        return NewPostgresRefreshTokenRepository(pool, opts...)
+    default:
+        return NewInMemoryRefreshTokenRepository()
+    }
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/JoaoVitorFerreiro/golang-start/internal/infra/repository")
+
+// startQuerySpan abre um span de cliente para uma query, com o SQL em db.statement.
+func startQuerySpan(ctx context.Context, spanName, operation, query string) (context.Context, trace.Span) {
+    return tracer.Start(ctx, spanName,
+        trace.WithSpanKind(trace.SpanKindClient),
+        trace.WithAttributes(
+            semconv.DBSystemPostgreSQL,
+            attribute.String("db.operation", operation),
+            attribute.String("db.statement", query),
+        ),
+    )
+}
+
+func endSpan(span trace.Span, err error) {
+    if err != nil {
+        span.RecordError(err)
+        span.SetStatus(codes.Error, err.Error())
+    }
+    span.End()
+}
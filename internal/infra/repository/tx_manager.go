@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+    DefaultTxIsolation  = pgx.RepeatableRead
+    DefaultTxMaxRetries = 3
+)
+
+// Repositories são os repositórios de uma unidade de trabalho: tudo o que
+// for gravado por eles é confirmado ou desfeito junto.
+type Repositories struct {
+    Users         UserRepository
+    Outbox        OutboxRepository
+    RefreshTokens RefreshTokenRepository
+    OneTimeTokens OneTimeTokenRepository
+}
+
+// TxManager executa fn numa unidade de trabalho. Se fn devolver erro (ou
+// entrar em pânico), nada do que gravou pelos repositórios recebidos fica.
+// fn pode rodar mais de uma vez quando a transação é repetida, então não
+// deve ter efeitos fora dos repositórios (emails, logs de sucesso etc.);
+// esses ficam para depois do WithinTx.
+type TxManager interface {
+    WithinTx(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
+}
+
+type txOptions struct {
+    isolation  pgx.TxIsoLevel
+    maxRetries int
+    repoOpts   []PostgresOption
+    decorate   func(Repositories) Repositories
+    logger     *slog.Logger
+}
+
+type TxOption func(*txOptions)
+
+// WithTxIsolation define o nível de isolamento das transações do Postgres
+// (o padrão é repeatable read).
+func WithTxIsolation(level pgx.TxIsoLevel) TxOption {
+    return func(o *txOptions) {
+        o.isolation = level
+    }
+}
+
+// WithTxMaxRetries define quantas vezes uma transação que falhou por
+// serialização (ou deadlock) é repetida antes de devolver o erro.
+func WithTxMaxRetries(retries int) TxOption {
+    return func(o *txOptions) {
+        o.maxRetries = retries
+    }
+}
+
+// WithTxRepositoryOptions repassa aos repositórios da transação as mesmas
+// opções dos repositórios do pool.
+func WithTxRepositoryOptions(opts ...PostgresOption) TxOption {
+    return func(o *txOptions) {
+        o.repoOpts = opts
+    }
+}
+
+// WithTxDecorator embrulha os repositórios de cada unidade de trabalho,
+// por exemplo com NewInstrumentedUserRepository.
+func WithTxDecorator(decorate func(Repositories) Repositories) TxOption {
+    return func(o *txOptions) {
+        o.decorate = decorate
+    }
+}
+
+// WithTxLogger define onde vão os avisos de transações repetidas.
+func WithTxLogger(logger *slog.Logger) TxOption {
+    return func(o *txOptions) {
+        o.logger = logger
+    }
+}
+
+func newTxOptions(opts []TxOption) txOptions {
+    o := txOptions{
+        isolation:  DefaultTxIsolation,
+        maxRetries: DefaultTxMaxRetries,
+        decorate:   func(repos Repositories) Repositories { return repos },
+        logger:     slog.Default(),
+    }
+    for _, opt := range opts {
+        opt(&o)
+    }
+    return o
+}
+
+// NewTxManager segue NewUserRepository: para InMemory, repos precisa trazer
+// os repositórios em memória (sem decorators), cujo estado será protegido;
+// os de tokens só entram nas unidades de trabalho se forem informados.
+func NewTxManager(repoType RepositoryType, pool *pgxpool.Pool, repos Repositories, opts ...TxOption) TxManager {
+    switch repoType {
+    case Postgres:
+        if pool == nil {
+            panic("pgxpool is required for postgres transactions")
+        }
+        return NewPostgresTxManager(pool, opts...)
+    default:
+        return NewInMemoryTxManager(repos, opts...)
+    }
+}
+
+// ParseTxIsolation aceita read_committed, repeatable_read e serializable.
+func ParseTxIsolation(value string) (pgx.TxIsoLevel, error) {
+    switch value {
+    case "read_committed":
+        return pgx.ReadCommitted, nil
+    case "repeatable_read":
+        return pgx.RepeatableRead, nil
+    case "serializable":
+        return pgx.Serializable, nil
+    default:
+        return "", fmt.Errorf("unknown transaction isolation level %q", value)
+    }
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DeliveryListOptions descreve uma página das entregas de uma inscrição,
+// da mais nova para a mais antiga. After é o cursor da última entrega vista.
+type DeliveryListOptions struct {
+    Limit  int
+    After  *Cursor
+    Status entity.WebhookDeliveryStatus
+}
+
+// WebhookDeliveryRepository guarda as entregas de webhook e suas tentativas.
+type WebhookDeliveryRepository interface {
+    // Add ignora as entregas cujo par (WebhookID, EventID) já existe: o
+    // relay pode publicar o mesmo evento mais de uma vez.
+    Add(ctx context.Context, deliveries ...*entity.WebhookDelivery) error
+    // ClaimDue reserva até limit entregas pendentes vencidas, adiando-as por
+    // lease, como OutboxRepository.ClaimDue.
+    ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error)
+    // RecordAttempts grava o estado da entrega e as tentativas em History,
+    // que devem ser só as novas desde a reserva.
+    RecordAttempts(ctx context.Context, delivery *entity.WebhookDelivery) error
+    // FindByID devolve a entrega com todas as tentativas, ou nil, nil se ela
+    // não existir ou for de outra inscrição.
+    FindByID(ctx context.Context, webhookID, id string) (*entity.WebhookDelivery, error)
+    // FindByWebhook lista as entregas sem History.
+    FindByWebhook(ctx context.Context, webhookID string, opts DeliveryListOptions) ([]*entity.WebhookDelivery, error)
+}
+
+func NewWebhookDeliveryRepository(repoType RepositoryType, pool *pgxpool.Pool, opts ...PostgresOption) WebhookDeliveryRepository {
+    switch repoType {
+    case Postgres:
+        if pool == nil {
+            panic("pgxpool is required for postgres repository")
+        }
+        return NewPostgresWebhookDeliveryRepository(pool, opts...)
+    default:
+        return NewInMemoryWebhookDeliveryRepository()
+    }
+}
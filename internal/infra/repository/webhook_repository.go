@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/JoaoVitorFerreiro/golang-start/internal/domain/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WebhookRepository guarda as inscrições. FindByID devolve nil, nil quando
+// a inscrição não existe; Delete devolve entity.ErrWebhookNotFound.
+type WebhookRepository interface {
+    Save(ctx context.Context, webhook *entity.Webhook) error
+    FindByID(ctx context.Context, id string) (*entity.Webhook, error)
+    // FindAll devolve as inscrições da mais antiga para a mais nova
+    FindAll(ctx context.Context) ([]*entity.Webhook, error)
+    Delete(ctx context.Context, id string) error
+}
+
+func NewWebhookRepository(repoType RepositoryType, pool *pgxpool.Pool, opts ...PostgresOption) WebhookRepository {
+    switch repoType {
+    case Postgres:
+        if pool == nil {
+            panic("pgxpool is required for postgres repository")
+        }
+        return NewPostgresWebhookRepository(pool, opts...)
+    default:
+        return NewInMemoryWebhookRepository()
+    }
+}
@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const ServiceName = "user-api"
+
+type Exporter string
+
+const (
+    ExporterNone   Exporter = "none"
+    ExporterStdout Exporter = "stdout"
+    ExporterFile   Exporter = "file"
+    ExporterOTLP   Exporter = "otlp"
+)
+
+type Config struct {
+    Exporter     Exporter
+    Environment  string
+    OTLPEndpoint string
+    FilePath     string
+    SampleRatio  float64
+}
+
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup configura o TracerProvider global e o propagador W3C (traceparent).
+// Com o exporter "none" o propagador continua ativo, mas nenhum span é exportado.
+func Setup(ctx context.Context, cfg Config) (ShutdownFunc, error) {
+    otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+        propagation.TraceContext{},
+        propagation.Baggage{},
+    ))
+    
+    if cfg.Exporter == ExporterNone || cfg.Exporter == "" {
+        return func(context.Context) error { return nil }, nil
+    }
+    
+    exporter, closer, err := newExporter(ctx, cfg)
+    if err != nil {
+        return nil, err
+    }
+    
+    res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+        semconv.SchemaURL,
+        semconv.ServiceName(ServiceName),
+        semconv.DeploymentEnvironment(cfg.Environment),
+    ))
+    if err != nil {
+        return nil, err
+    }
+    
+    provider := sdktrace.NewTracerProvider(
+        sdktrace.WithBatcher(exporter),
+        sdktrace.WithResource(res),
+        sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+    )
+    otel.SetTracerProvider(provider)
+    
+    return func(ctx context.Context) error {
+        err := provider.Shutdown(ctx)
+        if closer != nil {
+            if closeErr := closer.Close(); err == nil {
+                err = closeErr
+            }
+        }
+        return err
+    }, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, io.Closer, error) {
+    switch cfg.Exporter {
+    case ExporterStdout:
+        exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+        return exporter, nil, err
+    case ExporterFile:
+        file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+        if err != nil {
+            return nil, nil, fmt.Errorf("open trace file: %w", err)
+        }
+        exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+        if err != nil {
+            file.Close()
+            return nil, nil, err
+        }
+        return exporter, file, nil
+    case ExporterOTLP:
+        var opts []otlptracehttp.Option
+        if cfg.OTLPEndpoint != "" {
+            opts = append(opts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+        }
+        exporter, err := otlptracehttp.New(ctx, opts...)
+        return exporter, nil, err
+    default:
+        return nil, nil, fmt.Errorf("unknown tracing exporter %q (use none, stdout, file or otlp)", cfg.Exporter)
+    }
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+    DefaultTimeout = 10 * time.Second
+    userAgent      = "golang-start-webhooks/1.0"
+    // maxDrainBytes limita quanto da resposta é lido para reaproveitar a conexão
+    maxDrainBytes = 64 << 10
+)
+
+// Request é uma tentativa de entrega.
+type Request struct {
+    URL        string
+    Secret     string
+    DeliveryID string
+    EventType  string
+    Body       []byte
+}
+
+// Sender faz a chamada HTTP de uma entrega. Devolve o status recebido (zero
+// se não houve resposta) e erro para qualquer resposta fora de 2xx.
+type Sender interface {
+    Send(ctx context.Context, req Request) (int, error)
+}
+
+// StatusError é a resposta fora de 2xx do destino.
+type StatusError struct {
+    StatusCode int
+}
+
+func (e *StatusError) Error() string {
+    return fmt.Sprintf("webhook endpoint responded %d", e.StatusCode)
+}
+
+type Client struct {
+    http *http.Client
+    now  func() time.Time
+}
+
+// NewClient cria o Sender padrão. Redirecionamentos não são seguidos: a
+// URL cadastrada é o único destino que recebe o corpo assinado.
+func NewClient(timeout time.Duration) *Client {
+    if timeout <= 0 {
+        timeout = DefaultTimeout
+    }
+    return &Client{
+        http: &http.Client{
+            Timeout: timeout,
+            CheckRedirect: func(req *http.Request, via []*http.Request) error {
+                return http.ErrUseLastResponse
+            },
+        },
+        now: time.Now,
+    }
+}
+
+func (c *Client) Send(ctx context.Context, req Request) (int, error) {
+    httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+    if err != nil {
+        return 0, err
+    }
+
+    // O timestamp é o do envio, não o do evento: cada tentativa é assinada de novo
+    timestamp := c.now()
+    httpReq.Header.Set("Content-Type", "application/json")
+    httpReq.Header.Set("User-Agent", userAgent)
+    httpReq.Header.Set(HeaderID, req.DeliveryID)
+    httpReq.Header.Set(HeaderEvent, req.EventType)
+    httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+    httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+
+    resp, err := c.http.Do(httpReq)
+    if err != nil {
+        return 0, err
+    }
+    defer resp.Body.Close()
+    _, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+
+    if resp.StatusCode < 200 || resp.StatusCode > 299 {
+        return resp.StatusCode, &StatusError{StatusCode: resp.StatusCode}
+    }
+    return resp.StatusCode, nil
+}
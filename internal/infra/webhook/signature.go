@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers de cada entrega. O destino deve recalcular a assinatura sobre
+// "<timestamp>.<corpo>" com o segredo da inscrição e recusar timestamps
+// fora da tolerância, o que impede reenviar uma requisição capturada.
+const (
+    HeaderID        = "X-Webhook-Id"
+    HeaderEvent     = "X-Webhook-Event"
+    HeaderTimestamp = "X-Webhook-Timestamp"
+    HeaderSignature = "X-Webhook-Signature"
+
+    // signatureVersion prefixa a assinatura para permitir trocar o esquema
+    signatureVersion = "v1"
+)
+
+// DefaultTolerance é a diferença máxima aceita por Verify entre o
+// timestamp da entrega e o relógio de quem recebe.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+    ErrInvalidSignature = errors.New("invalid webhook signature")
+    ErrInvalidTimestamp = errors.New("webhook timestamp outside tolerance")
+)
+
+// Sign devolve o valor do header de assinatura: v1=<hex do HMAC-SHA256>.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+    return signatureVersion + "=" + hex.EncodeToString(mac(secret, strconv.FormatInt(timestamp.Unix(), 10), body))
+}
+
+// Verify confere uma entrega recebida. signature pode trazer mais de uma
+// assinatura separada por vírgula (durante a troca de segredo, por exemplo);
+// basta uma conferir.
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration, now time.Time) error {
+    seconds, err := strconv.ParseInt(timestamp, 10, 64)
+    if err != nil {
+        return ErrInvalidTimestamp
+    }
+    if diff := now.Sub(time.Unix(seconds, 0)); diff > tolerance || diff < -tolerance {
+        return ErrInvalidTimestamp
+    }
+
+    expected := mac(secret, timestamp, body)
+    for _, part := range strings.Split(signature, ",") {
+        version, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+        if !ok || version != signatureVersion {
+            continue
+        }
+        if got, err := hex.DecodeString(value); err == nil && hmac.Equal(got, expected) {
+            return nil
+        }
+    }
+    return ErrInvalidSignature
+}
+
+func mac(secret, timestamp string, body []byte) []byte {
+    h := hmac.New(sha256.New, []byte(secret))
+    h.Write([]byte(timestamp))
+    h.Write([]byte("."))
+    h.Write(body)
+    return h.Sum(nil)
+}
@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_SendsSignedRequest(t *testing.T) {
+    // Arrange
+    secret := "whsec_test-secret-123"
+    var verifyErr error
+    var headers http.Header
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        body, _ := io.ReadAll(r.Body)
+        headers = r.Header
+        verifyErr = Verify(secret, r.Header.Get(HeaderSignature), r.Header.Get(HeaderTimestamp), body, DefaultTolerance, time.Now())
+        w.WriteHeader(http.StatusNoContent)
+    }))
+    defer server.Close()
+    client := NewClient(time.Second)
+    
+    // Act
+    status, err := client.Send(context.Background(), Request{
+        URL:        server.URL,
+        Secret:     secret,
+        DeliveryID: "delivery-1",
+        EventType:  "user.created",
+        Body:       []byte(`{"id":"event-1"}`),
+    })
+    
+    // Assert
+    if err != nil || status != http.StatusNoContent {
+        t.Fatalf("Expected 204 without error, got %d and %v", status, err)
+    }
+    if verifyErr != nil {
+        t.Errorf("Expected receiver to verify the signature, got %v", verifyErr)
+    }
+    if headers.Get(HeaderID) != "delivery-1" || headers.Get(HeaderEvent) != "user.created" {
+        t.Errorf("Unexpected delivery headers %v", headers)
+    }
+}
+
+func TestClient_ReportsNon2xxAsError(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusServiceUnavailable)
+    }))
+    defer server.Close()
+    
+    status, err := NewClient(time.Second).Send(context.Background(), Request{URL: server.URL, Secret: "s"})
+    
+    var statusErr *StatusError
+    if status != http.StatusServiceUnavailable || !errors.As(err, &statusErr) {
+        t.Errorf("Expected StatusError with 503, got %d and %v", status, err)
+    }
+}
+
+func TestVerify_RejectsTamperingAndReplays(t *testing.T) {
+    secret := "whsec_test-secret-123"
+    body := []byte(`{"id":"event-1"}`)
+    signedAt := time.Unix(1_700_000_000, 0)
+    signature := Sign(secret, signedAt, body)
+    timestamp := "1700000000"
+    
+    tests := []struct {
+        name      string
+        secret    string
+        signature string
+        timestamp string
+        body      []byte
+        now       time.Time
+        want      error
+    }{
+        {"valid", secret, signature, timestamp, body, signedAt.Add(time.Minute), nil},
+        {"rotated secret list", secret, "v1=00," + signature, timestamp, body, signedAt, nil},
+        {"tampered body", secret, signature, timestamp, []byte(`{"id":"event-2"}`), signedAt, ErrInvalidSignature},
+        {"wrong secret", "whsec_other-secret-456", signature, timestamp, body, signedAt, ErrInvalidSignature},
+        {"timestamp changed", secret, signature, "1700000001", body, signedAt, ErrInvalidSignature},
+        {"replayed later", secret, signature, timestamp, body, signedAt.Add(DefaultTolerance + time.Second), ErrInvalidTimestamp},
+        {"malformed timestamp", secret, signature, "ontem", body, signedAt, ErrInvalidTimestamp},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := Verify(tt.secret, tt.signature, tt.timestamp, tt.body, DefaultTolerance, tt.now)
+            if !errors.Is(err, tt.want) {
+                t.Errorf("Expected %v, got %v", tt.want, err)
+            }
+        })
+    }
+}